@@ -0,0 +1,575 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ArchieAdams/tag-game/internal/game"
+	"github.com/ArchieAdams/tag-game/internal/store"
+)
+
+func (s *Server) createGameAndPlayer(ctx context.Context, gameId, gameName, playerId, playerName string, settings game.Settings) error {
+	settings = settings.WithDefaults()
+	if err := game.ValidateMode(settings.Mode); err != nil {
+		return err
+	}
+	g := game.Game{GameId: gameId, GameName: gameName, HasGameStarted: false, OwnerId: playerId, Settings: settings}
+	p := game.Player{PlayerId: playerId, PlayerName: playerName, GameId: gameId, Alive: true}
+	return s.store.CreateGameAndPlayer(ctx, g, p)
+}
+
+// joinGame returns the game being joined, having first rejected the join if
+// it would exceed the game's Settings.MaxPlayers. This is a best-effort
+// check, not an atomic reservation: two joins racing right at the cap can
+// both pass it, the same way two concurrent requests can otherwise always
+// interleave against this store.
+func (s *Server) joinGame(ctx context.Context, gameId string) (*game.Game, error) {
+	g, err := s.store.GetGame(ctx, gameId)
+	if err != nil {
+		return nil, err
+	}
+	if g.Settings.MaxPlayers > 0 {
+		players, err := s.store.PlayersByGame(ctx, gameId)
+		if err != nil {
+			return nil, err
+		}
+		if len(players) >= g.Settings.MaxPlayers {
+			return nil, game.ErrGameFull
+		}
+	}
+	return g, nil
+}
+
+func (s *Server) createPlayer(ctx context.Context, gameId, playerId, playerName string) error {
+	existing, err := s.store.GetPlayer(ctx, playerId)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+	if err == nil && existing.Left {
+		if existing.GameId == gameId {
+			return game.ErrRejoinNotAllowed
+		}
+		// existing is a tombstone left behind in a different game; playerId
+		// is keyed globally, so it has to be cleared before CreatePlayer's
+		// attribute_not_exists(playerId) condition will let this game's join
+		// through.
+		if err := s.store.DeletePlayer(ctx, playerId); err != nil {
+			return err
+		}
+	}
+
+	if err := s.store.CreatePlayer(ctx, game.Player{PlayerId: playerId, PlayerName: playerName, GameId: gameId, Alive: true}); err != nil {
+		return err
+	}
+	s.broadcast(ctx, gameId, map[string]any{
+		"type":       "player_joined",
+		"gameId":     gameId,
+		"playerId":   playerId,
+		"playerName": playerName,
+	})
+	return nil
+}
+
+func (s *Server) isGameOwner(ctx context.Context, gameId, playerId string) (bool, error) {
+	g, err := s.store.GetGame(ctx, gameId)
+	if err != nil {
+		return false, err
+	}
+	return g.OwnerId == playerId, nil
+}
+
+// requireOwner returns game.ErrNotOwner unless playerId owns gameId.
+func (s *Server) requireOwner(ctx context.Context, gameId, playerId string) error {
+	isOwner, err := s.isGameOwner(ctx, gameId, playerId)
+	if err != nil {
+		return err
+	}
+	if !isOwner {
+		return game.ErrNotOwner
+	}
+	return nil
+}
+
+func (s *Server) deleteGame(ctx context.Context, gameId, playerId string) error {
+	if err := s.requireOwner(ctx, gameId, playerId); err != nil {
+		return err
+	}
+	return s.store.DeleteGame(ctx, gameId)
+}
+
+func (s *Server) removePlayer(ctx context.Context, gameId, playerId, targetId string) error {
+	if err := s.requireOwner(ctx, gameId, playerId); err != nil {
+		return err
+	}
+	if err := s.store.DeletePlayer(ctx, targetId); err != nil {
+		return err
+	}
+	s.dropPlayerConnections(ctx, gameId, targetId)
+	s.broadcast(ctx, gameId, map[string]any{"type": "player_removed", "gameId": gameId, "playerId": targetId})
+	return nil
+}
+
+// dropPlayerConnections closes out any WebSocket subscriptions a player who
+// just left or was removed from gameId still holds, so they stop receiving
+// that game's broadcasts. Best-effort: a stale connection is otherwise
+// cleaned up the next time a push to it comes back GoneException.
+func (s *Server) dropPlayerConnections(ctx context.Context, gameId, playerId string) {
+	conns, err := s.store.ConnectionsByGame(ctx, gameId)
+	if err != nil {
+		log.Printf("failed to list connections for game %s: %v", gameId, err)
+		return
+	}
+	for _, c := range conns {
+		if c.PlayerId != playerId {
+			continue
+		}
+		if err := s.store.DeleteConnection(ctx, c.GameId, c.ConnectionId); err != nil {
+			log.Printf("failed to drop connection %s for player %s: %v", c.ConnectionId, playerId, err)
+		}
+	}
+}
+
+// pickRandomItPlayer marks one of players "it" at random and returns their
+// ID. It takes an already-fetched player list rather than querying for one,
+// since every caller already has it in hand.
+func (s *Server) pickRandomItPlayer(ctx context.Context, gameId string, players []game.Player) (string, error) {
+	if len(players) == 0 {
+		return "", game.ErrNoPlayers
+	}
+
+	itPlayer := players[rand.Intn(len(players))]
+	if err := s.store.SetItPlayer(ctx, gameId, players, itPlayer.PlayerId); err != nil {
+		return "", err
+	}
+	return itPlayer.PlayerId, nil
+}
+
+func (s *Server) startGame(ctx context.Context, gameId, playerId string) error {
+	if err := s.requireOwner(ctx, gameId, playerId); err != nil {
+		return err
+	}
+
+	g, err := s.store.GetGame(ctx, gameId)
+	if err != nil {
+		return err
+	}
+	players, err := s.store.PlayersByGame(ctx, gameId)
+	if err != nil {
+		return err
+	}
+	if len(players) < g.Settings.MinPlayersToStart {
+		return game.ErrNotEnoughPlayers
+	}
+
+	itPlayerId, err := s.pickRandomItPlayer(ctx, gameId, players)
+	if err != nil {
+		return err
+	}
+
+	startedAt := time.Now().UnixMilli()
+	var endsAt int64
+	if g.Settings.RoundSeconds > 0 {
+		endsAt = startedAt + int64(g.Settings.RoundSeconds)*1000
+	}
+	if err := s.store.StartGameState(ctx, gameId, startedAt, endsAt); err != nil {
+		return err
+	}
+	if endsAt > 0 {
+		s.scheduleRoundEnd(ctx, gameId, time.UnixMilli(endsAt))
+	}
+
+	s.broadcast(ctx, gameId, map[string]any{"type": "game_started", "gameId": gameId, "itPlayerId": itPlayerId})
+	return nil
+}
+
+// scheduleRoundEnd arranges for tickGame to be called once endsAt arrives,
+// if this Server was built with a scheduler. Best-effort: a timed round that
+// fails to schedule its end still plays out normally, it just relies on the
+// owner to call /endGame manually.
+func (s *Server) scheduleRoundEnd(ctx context.Context, gameId string, endsAt time.Time) {
+	if s.scheduler == nil {
+		return
+	}
+	if err := s.scheduler.ScheduleGameEnd(ctx, gameId, endsAt); err != nil {
+		log.Printf("failed to schedule round end for game %s: %v", gameId, err)
+	}
+}
+
+// cancelRoundEnd cancels the scheduled round-end rule for gameId, if this
+// Server was built with a scheduler. Only called for games that actually had
+// a timer armed, so it's never asking EventBridge to cancel a rule that was
+// never created.
+func (s *Server) cancelRoundEnd(ctx context.Context, gameId string) {
+	if s.scheduler == nil {
+		return
+	}
+	if err := s.scheduler.CancelGameEnd(ctx, gameId); err != nil {
+		log.Printf("failed to cancel round end for game %s: %v", gameId, err)
+	}
+}
+
+// endGame is the owner-initiated way to finish a game early.
+func (s *Server) endGame(ctx context.Context, gameId, playerId string) error {
+	g, err := s.store.GetGame(ctx, gameId)
+	if err != nil {
+		return err
+	}
+	if g.OwnerId != playerId {
+		return game.ErrNotOwner
+	}
+	return s.finishGame(ctx, g)
+}
+
+// tickGame is called by the EventBridge rule scheduled for a timed round's
+// end. It's a no-op if the game already ended by some other means (an early
+// /endGame, or a rule that fired twice).
+func (s *Server) tickGame(ctx context.Context, gameId string) error {
+	g, err := s.store.GetGame(ctx, gameId)
+	if err != nil {
+		return err
+	}
+	if !g.HasGameStarted {
+		return nil
+	}
+	return s.finishGame(ctx, g)
+}
+
+// finishGame ends the game, archives its final state, and folds each
+// player's result into the leaderboard aggregates. The archive write is the
+// authoritative record of the game ending; leaderboard updates are
+// best-effort and logged on failure rather than failing the request, since
+// stats can always be recomputed from the archive later.
+func (s *Server) finishGame(ctx context.Context, g *game.Game) error {
+	players, err := s.store.PlayersByGame(ctx, g.GameId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.SetGameState(ctx, g.GameId, false); err != nil {
+		return err
+	}
+	if g.EndsAt > 0 {
+		s.cancelRoundEnd(ctx, g.GameId)
+	}
+
+	archived := s.buildArchive(*g, players)
+	if err := s.store.ArchiveGame(ctx, archived); err != nil {
+		return err
+	}
+
+	s.recordLeaderboardStats(ctx, archived)
+	s.broadcast(ctx, g.GameId, map[string]any{"type": "game_ended", "gameId": g.GameId})
+
+	return nil
+}
+
+func (s *Server) buildArchive(g game.Game, players []game.Player) game.ArchivedGame {
+	endedAt := time.Now().UnixMilli()
+	archived := game.ArchivedGame{
+		GameId:   g.GameId,
+		GameName: g.GameName,
+		OwnerId:  g.OwnerId,
+		EndedAt:  endedAt,
+		Players:  make([]game.ArchivedPlayerStats, 0, len(players)),
+	}
+
+	for _, p := range players {
+		survivedSince := g.StartedAt
+		if p.LastTaggedAt != 0 {
+			survivedSince = p.LastTaggedAt
+		}
+		archived.Players = append(archived.Players, game.ArchivedPlayerStats{
+			PlayerId:    p.PlayerId,
+			PlayerName:  p.PlayerName,
+			Alive:       p.Alive,
+			WasLastIt:   p.IsIt,
+			TagsMade:    p.TagsMade,
+			TimesTagged: p.TimesTagged,
+			SurvivedMs:  endedAt - survivedSince,
+		})
+	}
+
+	return archived
+}
+
+func (s *Server) recordLeaderboardStats(ctx context.Context, archived game.ArchivedGame) {
+	now := time.Now()
+	windows := []game.LeaderboardWindow{game.WindowDaily, game.WindowWeekly, game.WindowAllTime}
+
+	for _, window := range windows {
+		bucket, err := game.WindowBucket(window, now)
+		if err != nil {
+			log.Printf("failed to compute %s leaderboard bucket: %v", window, err)
+			continue
+		}
+		for _, p := range archived.Players {
+			err := s.store.IncrementPlayerStats(ctx, bucket, p.PlayerId, p.PlayerName, p.TagsMade, p.Won())
+			if err != nil {
+				log.Printf("failed to update %s leaderboard stats for player %s: %v", window, p.PlayerId, err)
+			}
+		}
+	}
+}
+
+func (s *Server) playerList(ctx context.Context, gameId, playerId string) ([]game.Player, error) {
+	if err := s.requireOwner(ctx, gameId, playerId); err != nil {
+		return nil, err
+	}
+	return s.store.PlayersByGame(ctx, gameId)
+}
+
+// leaveGame removes playerId from their game. If the game's
+// Settings.AllowRejoin is false, their row is kept and flagged Left instead
+// of deleted, so they can't rejoin under the same ID later.
+func (s *Server) leaveGame(ctx context.Context, playerId string) error {
+	p, err := s.store.GetPlayer(ctx, playerId)
+	if err != nil {
+		return err
+	}
+	g, err := s.store.GetGame(ctx, p.GameId)
+	if err != nil {
+		return err
+	}
+
+	if g.Settings.AllowRejoin {
+		if err := s.store.DeletePlayer(ctx, playerId); err != nil {
+			return err
+		}
+	} else {
+		if err := s.store.MarkPlayerLeft(ctx, playerId); err != nil {
+			return err
+		}
+	}
+
+	s.dropPlayerConnections(ctx, p.GameId, playerId)
+	s.broadcast(ctx, p.GameId, map[string]any{"type": "player_left", "gameId": p.GameId, "playerId": playerId})
+	return nil
+}
+
+func (s *Server) gameState(ctx context.Context, gameId string) (bool, error) {
+	g, err := s.store.GetGame(ctx, gameId)
+	if err != nil {
+		return false, err
+	}
+	return g.HasGameStarted, nil
+}
+
+func (s *Server) setIt(ctx context.Context, gameId, playerId, targetId string) error {
+	if err := s.requireOwner(ctx, gameId, playerId); err != nil {
+		return err
+	}
+
+	players, err := s.store.PlayersByGame(ctx, gameId)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, p := range players {
+		if p.PlayerId == targetId {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return game.ErrDifferentGame
+	}
+
+	if err := s.store.SetItPlayer(ctx, gameId, players, targetId); err != nil {
+		return err
+	}
+	s.broadcast(ctx, gameId, map[string]any{"type": "it_changed", "gameId": gameId, "itPlayerId": targetId})
+	return nil
+}
+
+func (s *Server) getItPlayer(ctx context.Context, gameId string) (*game.Player, error) {
+	players, err := s.store.PlayersByGame(ctx, gameId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range players {
+		if p.IsIt {
+			return &p, nil
+		}
+	}
+
+	return nil, game.ErrNoItPlayer
+}
+
+func (s *Server) eliminate(ctx context.Context, gameId, playerId, targetId string) error {
+	if err := s.requireOwner(ctx, gameId, playerId); err != nil {
+		return err
+	}
+	if err := s.store.SetPlayerAlive(ctx, gameId, targetId, false); err != nil {
+		return err
+	}
+	s.broadcast(ctx, gameId, map[string]any{"type": "eliminated", "gameId": gameId, "playerId": targetId})
+	return nil
+}
+
+// tag validates and records a tag, dispatching its effect according to the
+// game's mode.
+func (s *Server) tag(ctx context.Context, gameId, playerId, targetId string) error {
+	g, err := s.store.GetGame(ctx, gameId)
+	if err != nil {
+		return err
+	}
+
+	tagger, err := s.store.GetPlayer(ctx, playerId)
+	if err != nil {
+		return err
+	}
+	if tagger.GameId != gameId {
+		return game.ErrDifferentGame
+	}
+
+	target, err := s.store.GetPlayer(ctx, targetId)
+	if err != nil {
+		return err
+	}
+	if target.GameId != gameId {
+		return game.ErrDifferentGame
+	}
+
+	if err := game.ValidateTag(*tagger, *target); err != nil {
+		return err
+	}
+
+	event := game.TagEvent{
+		GameId:    gameId,
+		EventId:   uuid.NewString(),
+		TaggerId:  playerId,
+		TargetId:  targetId,
+		CreatedAt: time.Now().UnixMilli(),
+	}
+
+	eventType, err := s.recordTagByMode(ctx, g.Settings.Mode, *tagger, *target, event)
+	if err != nil {
+		return err
+	}
+
+	s.broadcast(ctx, gameId, map[string]any{
+		"type":     eventType,
+		"gameId":   gameId,
+		"taggerId": playerId,
+		"targetId": targetId,
+	})
+	return nil
+}
+
+// recordTagByMode persists a validated tag according to mode and reports the
+// broadcast event type it produced: classic flips "it" from tagger to
+// target, freeze freezes the target in place, and deathmatch eliminates the
+// target. Freeze and deathmatch both leave "it" with the tagger.
+func (s *Server) recordTagByMode(ctx context.Context, mode game.GameMode, tagger, target game.Player, event game.TagEvent) (string, error) {
+	switch mode {
+	case game.ModeFreeze:
+		if err := s.store.RecordFreezeTag(ctx, tagger, target, event); err != nil {
+			return "", err
+		}
+		return "frozen", nil
+	case game.ModeDeathmatch:
+		if err := s.store.RecordEliminationTag(ctx, tagger, target, event); err != nil {
+			return "", err
+		}
+		return "eliminated", nil
+	default:
+		if err := s.store.RecordTag(ctx, tagger, target, event); err != nil {
+			return "", err
+		}
+		return "tagged", nil
+	}
+}
+
+// unfreeze lets an unfrozen player in the game free a frozen teammate,
+// clearing their Frozen flag so they can tag and be tagged again.
+func (s *Server) unfreeze(ctx context.Context, gameId, playerId, targetId string) error {
+	freer, err := s.store.GetPlayer(ctx, playerId)
+	if err != nil {
+		return err
+	}
+	if freer.GameId != gameId {
+		return game.ErrDifferentGame
+	}
+	if freer.Frozen {
+		return game.ErrAlreadyFrozen
+	}
+
+	target, err := s.store.GetPlayer(ctx, targetId)
+	if err != nil {
+		return err
+	}
+	if target.GameId != gameId {
+		return game.ErrDifferentGame
+	}
+	if !target.Frozen {
+		return game.ErrNotFrozen
+	}
+
+	if err := s.store.Unfreeze(ctx, gameId, targetId); err != nil {
+		return err
+	}
+
+	s.broadcast(ctx, gameId, map[string]any{
+		"type":     "unfrozen",
+		"gameId":   gameId,
+		"playerId": targetId,
+	})
+	return nil
+}
+
+// viewerCanSeeArchive reports whether playerId was the owner or a
+// participant of archived, so archive/game doesn't leak other players'
+// finished-game stats to strangers.
+func viewerCanSeeArchive(archived *game.ArchivedGame, playerId string) bool {
+	if archived.OwnerId == playerId {
+		return true
+	}
+	for _, p := range archived.Players {
+		if p.PlayerId == playerId {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) getArchivedGame(ctx context.Context, gameId, playerId string) (*game.ArchivedGame, error) {
+	archived, err := s.store.GetArchivedGame(ctx, gameId)
+	if err != nil {
+		return nil, err
+	}
+	if !viewerCanSeeArchive(archived, playerId) {
+		return nil, game.ErrNotParticipant
+	}
+	return archived, nil
+}
+
+func (s *Server) listArchivedGamesByOwner(ctx context.Context, ownerId string, limit int, cursor string) ([]game.ArchivedGame, string, error) {
+	return s.store.ListArchivedGamesByOwner(ctx, ownerId, limit, cursor)
+}
+
+func (s *Server) leaderboard(ctx context.Context, window game.LeaderboardWindow, limit int, cursor string) ([]game.PlayerStats, string, error) {
+	bucket, err := game.WindowBucket(window, time.Now())
+	if err != nil {
+		return nil, "", err
+	}
+	return s.store.Leaderboard(ctx, bucket, limit, cursor)
+}
+
+func (s *Server) rankingPageCount(ctx context.Context, window game.LeaderboardWindow, pageSize int) (int, error) {
+	bucket, err := game.WindowBucket(window, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	count, err := s.store.CountPlayerStats(ctx, bucket)
+	if err != nil {
+		return 0, err
+	}
+	return (count + pageSize - 1) / pageSize, nil
+}
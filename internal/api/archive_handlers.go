@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/ArchieAdams/tag-game/internal/game"
+)
+
+// defaultArchivePageSize is used for /archive/list and the leaderboard
+// endpoints when the caller doesn't specify a limit.
+const defaultArchivePageSize = 20
+
+func (s *Server) handleArchiveGame(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	gameId := request.QueryStringParameters["gameId"]
+	if gameId == "" {
+		return writeError(httpErr(400, errors.New("gameId is required")))
+	}
+
+	playerId, _, err := s.authenticate(ctx, request)
+	if err != nil {
+		return writeError(err)
+	}
+
+	archived, err := s.getArchivedGame(ctx, gameId, playerId)
+	if err != nil {
+		return writeError(fmt.Errorf("get archived game failed: %w", err))
+	}
+
+	return writeJSON(200, archived)
+}
+
+func (s *Server) handleArchiveList(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	ownerId := request.QueryStringParameters["ownerId"]
+	if ownerId == "" {
+		return writeError(httpErr(400, errors.New("ownerId is required")))
+	}
+
+	playerId, _, err := s.authenticate(ctx, request)
+	if err != nil {
+		return writeError(err)
+	}
+	if playerId != ownerId {
+		return writeError(httpErr(403, game.ErrNotParticipant))
+	}
+
+	limit, err := parseLimit(request.QueryStringParameters["limit"], defaultArchivePageSize)
+	if err != nil {
+		return writeError(httpErr(400, err))
+	}
+
+	games, nextCursor, err := s.listArchivedGamesByOwner(ctx, ownerId, limit, request.QueryStringParameters["cursor"])
+	if err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("list archived games failed: %w", err)))
+	}
+
+	return writeJSON(200, map[string]any{
+		"games":  games,
+		"cursor": nextCursor,
+	})
+}
+
+func (s *Server) handleLeaderboardGlobal(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	window, err := parseWindow(request.QueryStringParameters["window"])
+	if err != nil {
+		return writeError(httpErr(400, err))
+	}
+
+	limit, err := parseLimit(request.QueryStringParameters["limit"], defaultArchivePageSize)
+	if err != nil {
+		return writeError(httpErr(400, err))
+	}
+
+	entries, nextCursor, err := s.leaderboard(ctx, window, limit, request.QueryStringParameters["cursor"])
+	if err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("leaderboard failed: %w", err)))
+	}
+
+	return writeJSON(200, map[string]any{
+		"entries": entries,
+		"cursor":  nextCursor,
+	})
+}
+
+func (s *Server) handleRankingPageCount(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	window, err := parseWindow(request.QueryStringParameters["window"])
+	if err != nil {
+		return writeError(httpErr(400, err))
+	}
+
+	pageSize, err := parseLimit(request.QueryStringParameters["pageSize"], defaultArchivePageSize)
+	if err != nil {
+		return writeError(httpErr(400, err))
+	}
+
+	pageCount, err := s.rankingPageCount(ctx, window, pageSize)
+	if err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("ranking page count failed: %w", err)))
+	}
+
+	return writeJSON(200, map[string]int{"pageCount": pageCount})
+}
+
+func parseWindow(raw string) (game.LeaderboardWindow, error) {
+	switch game.LeaderboardWindow(raw) {
+	case game.WindowDaily, game.WindowWeekly, game.WindowAllTime:
+		return game.LeaderboardWindow(raw), nil
+	default:
+		return "", game.ErrInvalidWindow
+	}
+}
+
+func parseLimit(raw string, fallback int) (int, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf("limit must be a positive integer")
+	}
+	return limit, nil
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/ArchieAdams/tag-game/internal/game"
+	"github.com/ArchieAdams/tag-game/internal/store"
+)
+
+// sessionTTL is how long an issued session token remains valid.
+const sessionTTL = 24 * time.Hour
+
+// errUnauthenticated is returned when a request has no valid bearer session.
+var errUnauthenticated = errors.New("invalid or expired session")
+
+func generateSessionToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := cryptorand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate session token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func hashSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// createSession issues a new bearer token for playerId in gameId and
+// persists its hash, returning the raw token to hand back to the client.
+func (s *Server) createSession(ctx context.Context, playerId, gameId string) (string, error) {
+	token, err := generateSessionToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = s.store.PutSession(ctx, game.Session{
+		TokenHash: hashSessionToken(token),
+		PlayerId:  playerId,
+		GameId:    gameId,
+		ExpiresAt: time.Now().Add(sessionTTL).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return token, nil
+}
+
+func bearerToken(request events.APIGatewayWebsocketProxyRequest) (string, error) {
+	authHeader := request.Headers["Authorization"]
+	if authHeader == "" {
+		authHeader = request.Headers["authorization"]
+	}
+	if !strings.HasPrefix(authHeader, "Bearer ") {
+		return "", errUnauthenticated
+	}
+	return strings.TrimPrefix(authHeader, "Bearer "), nil
+}
+
+// authenticate resolves the caller's playerId and gameId from the bearer
+// token on the request, so handlers never have to trust a body-provided
+// playerId.
+func (s *Server) authenticate(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (playerId string, gameId string, err error) {
+	token, err := bearerToken(request)
+	if err != nil {
+		return "", "", err
+	}
+
+	sess, err := s.store.GetSession(ctx, hashSessionToken(token))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return "", "", errUnauthenticated
+		}
+		return "", "", fmt.Errorf("failed to look up session: %w", err)
+	}
+	if time.Now().Unix() > sess.ExpiresAt {
+		return "", "", errUnauthenticated
+	}
+
+	return sess.PlayerId, sess.GameId, nil
+}
+
+func (s *Server) deleteSession(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) error {
+	token, err := bearerToken(request)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.DeleteSession(ctx, hashSessionToken(token)); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/ArchieAdams/tag-game/internal/game"
+	"github.com/ArchieAdams/tag-game/internal/store"
+)
+
+// HTTPError pairs an error with the status code a handler wants written for
+// it, so writeError never has to guess from an error string.
+type HTTPError struct {
+	Status int
+	Err    error
+}
+
+func (e *HTTPError) Error() string { return e.Err.Error() }
+func (e *HTTPError) Unwrap() error { return e.Err }
+
+func httpErr(status int, err error) error {
+	return &HTTPError{Status: status, Err: err}
+}
+
+// classify maps a domain/store error to the status code it should produce
+// when a handler didn't already wrap it in an HTTPError.
+func classify(err error) int {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, store.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, game.ErrNotOwner), errors.Is(err, game.ErrNotParticipant):
+		return http.StatusForbidden
+	case errors.Is(err, game.ErrNotIt), errors.Is(err, game.ErrTargetNotAlive), errors.Is(err, game.ErrDifferentGame), errors.Is(err, game.ErrNoPlayers):
+		return http.StatusConflict
+	case errors.Is(err, game.ErrNoItPlayer):
+		return http.StatusNotFound
+	case errors.Is(err, game.ErrGameFull), errors.Is(err, game.ErrNotEnoughPlayers), errors.Is(err, game.ErrNotFrozen), errors.Is(err, game.ErrAlreadyFrozen), errors.Is(err, game.ErrRejoinNotAllowed):
+		return http.StatusConflict
+	case errors.Is(err, game.ErrInvalidMode), errors.Is(err, game.ErrInvalidWindow):
+		return http.StatusBadRequest
+	case errors.Is(err, errUnauthenticated):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeError(err error) (events.APIGatewayProxyResponse, error) {
+	status := classify(err)
+	var httpError *HTTPError
+	if errors.As(err, &httpError) {
+		status = httpError.Status
+		err = httpError.Err
+	}
+
+	body, marshalErr := json.Marshal(map[string]string{"error": err.Error()})
+	if marshalErr != nil {
+		body = []byte(fmt.Sprintf(`{"error": %q}`, err.Error()))
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: status,
+		Body:       string(body),
+		Headers:    map[string]string{"Content-Type": "application/json"},
+	}, nil
+}
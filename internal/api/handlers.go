@@ -0,0 +1,359 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/ArchieAdams/tag-game/internal/game"
+	"github.com/ArchieAdams/tag-game/internal/store"
+)
+
+func (s *Server) handleCreateGame(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var body CreateGameRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid game request")))
+	}
+
+	if err := s.createGameAndPlayer(ctx, body.GameId, body.GameName, body.PlayerId, body.PlayerName, body.Settings); err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			return writeError(httpErr(409, errors.New("game already exists or player already joined")))
+		}
+		if errors.Is(err, game.ErrInvalidMode) {
+			return writeError(httpErr(400, err))
+		}
+		return writeError(httpErr(500, fmt.Errorf("create failed: %w", err)))
+	}
+
+	token, err := s.createSession(ctx, body.PlayerId, body.GameId)
+	if err != nil {
+		return writeError(httpErr(500, fmt.Errorf("failed to create session: %w", err)))
+	}
+
+	return writeJSON(200, map[string]string{
+		"message": fmt.Sprintf("%s has been made by %s", body.GameName, body.PlayerName),
+		"token":   token,
+	})
+}
+
+func (s *Server) handleJoinGame(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var body JoinGameRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid JSON in request body")))
+	}
+
+	if _, err := s.joinGame(ctx, body.GameId); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return writeError(httpErr(404, fmt.Errorf("game not found: %w", err)))
+		}
+		return writeError(httpErr(classify(err), fmt.Errorf("join failed: %w", err)))
+	}
+
+	if err := s.createPlayer(ctx, body.GameId, body.PlayerId, body.PlayerName); err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			return writeError(httpErr(409, errors.New("player already exists in this game")))
+		}
+		if errors.Is(err, game.ErrRejoinNotAllowed) {
+			return writeError(httpErr(409, err))
+		}
+		return writeError(httpErr(500, fmt.Errorf("failed to create player: %w", err)))
+	}
+
+	token, err := s.createSession(ctx, body.PlayerId, body.GameId)
+	if err != nil {
+		return writeError(httpErr(500, fmt.Errorf("failed to create session: %w", err)))
+	}
+
+	return writeJSON(200, map[string]string{
+		"message": fmt.Sprintf("Player %s joined game %s", body.PlayerName, body.GameId),
+		"token":   token,
+	})
+}
+
+func (s *Server) handleDeleteGame(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var body GameRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid delete game request")))
+	}
+
+	playerId, _, err := s.authenticate(ctx, request)
+	if err != nil {
+		return writeError(err)
+	}
+	body.PlayerId = playerId
+
+	if err := s.deleteGame(ctx, body.GameId, body.PlayerId); err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("delete failed: %w", err)))
+	}
+
+	return writeText(200, fmt.Sprintf("Game %s and associated players deleted successfully", body.GameId))
+}
+
+func (s *Server) handleRemovePlayer(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var body RemovePlayerRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid player remove request")))
+	}
+
+	playerId, _, err := s.authenticate(ctx, request)
+	if err != nil {
+		return writeError(err)
+	}
+	body.PlayerId = playerId
+
+	if err := s.removePlayer(ctx, body.GameId, body.PlayerId, body.PlayerIdToRemove); err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("remove failed: %w", err)))
+	}
+
+	return writeText(200, fmt.Sprintf("Player reomved %s and associated players deleted successfully", body.PlayerIdToRemove))
+}
+
+func (s *Server) handleStartGame(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var body GameRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid start game request")))
+	}
+
+	playerId, _, err := s.authenticate(ctx, request)
+	if err != nil {
+		return writeError(err)
+	}
+	body.PlayerId = playerId
+
+	if err := s.startGame(ctx, body.GameId, body.PlayerId); err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("start game failed: %w", err)))
+	}
+
+	return writeText(200, fmt.Sprintf("Game started: %s", body.GameId))
+}
+
+func (s *Server) handleEndGame(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var body GameRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid end game request")))
+	}
+
+	playerId, _, err := s.authenticate(ctx, request)
+	if err != nil {
+		return writeError(err)
+	}
+	body.PlayerId = playerId
+
+	if err := s.endGame(ctx, body.GameId, body.PlayerId); err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("end game failed: %w", err)))
+	}
+
+	return writeText(200, fmt.Sprintf("Game ended: %s", body.GameId))
+}
+
+func (s *Server) handlePlayerList(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var body GameRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid player list request")))
+	}
+
+	playerId, _, err := s.authenticate(ctx, request)
+	if err != nil {
+		return writeError(err)
+	}
+	body.PlayerId = playerId
+
+	players, err := s.playerList(ctx, body.GameId, body.PlayerId)
+	if err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("player list failed: %w", err)))
+	}
+
+	return writeJSON(200, players)
+}
+
+func (s *Server) handleLeaveGame(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var body GameRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid leave game request")))
+	}
+
+	playerId, _, err := s.authenticate(ctx, request)
+	if err != nil {
+		return writeError(err)
+	}
+	body.PlayerId = playerId
+
+	if err := s.leaveGame(ctx, body.PlayerId); err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("leave game failed: %w", err)))
+	}
+
+	return writeText(200, fmt.Sprintf("Player Left: %s", body.PlayerId))
+}
+
+func (s *Server) handleGameState(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var body GameRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid game state request")))
+	}
+
+	started, err := s.gameState(ctx, body.GameId)
+	if err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("game state failed: %w", err)))
+	}
+
+	return writeJSON(200, map[string]bool{"gameState": started})
+}
+
+func (s *Server) handleIsOwner(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var body GameRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid is owner request")))
+	}
+
+	playerId, _, err := s.authenticate(ctx, request)
+	if err != nil {
+		return writeError(err)
+	}
+	body.PlayerId = playerId
+
+	isOwner, err := s.isGameOwner(ctx, body.GameId, body.PlayerId)
+	if err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("is owner failed: %w", err)))
+	}
+
+	return writeJSON(200, map[string]bool{"isOwner": isOwner})
+}
+
+func (s *Server) handleTag(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var body TagRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid tag request")))
+	}
+
+	playerId, _, err := s.authenticate(ctx, request)
+	if err != nil {
+		return writeError(err)
+	}
+	body.PlayerId = playerId
+
+	if err := s.tag(ctx, body.GameId, body.PlayerId, body.TargetId); err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("tag failed: %w", err)))
+	}
+
+	return writeJSON(200, map[string]string{
+		"message": fmt.Sprintf("%s tagged %s", body.PlayerId, body.TargetId),
+	})
+}
+
+func (s *Server) handleGetItPlayer(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var body GameRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid get it player request")))
+	}
+
+	playerId, _, err := s.authenticate(ctx, request)
+	if err != nil {
+		return writeError(err)
+	}
+	body.PlayerId = playerId
+
+	itPlayer, err := s.getItPlayer(ctx, body.GameId)
+	if err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("get it player failed: %w", err)))
+	}
+
+	return writeJSON(200, itPlayer)
+}
+
+func (s *Server) handleEliminate(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var body EliminateRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid eliminate request")))
+	}
+
+	playerId, _, err := s.authenticate(ctx, request)
+	if err != nil {
+		return writeError(err)
+	}
+	body.PlayerId = playerId
+
+	if err := s.eliminate(ctx, body.GameId, body.PlayerId, body.TargetId); err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("eliminate failed: %w", err)))
+	}
+
+	return writeText(200, fmt.Sprintf("Player eliminated: %s", body.TargetId))
+}
+
+func (s *Server) handleSetIt(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var body SetItRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid set it request")))
+	}
+
+	playerId, _, err := s.authenticate(ctx, request)
+	if err != nil {
+		return writeError(err)
+	}
+	body.PlayerId = playerId
+
+	if err := s.setIt(ctx, body.GameId, body.PlayerId, body.TargetId); err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("set it failed: %w", err)))
+	}
+
+	return writeText(200, fmt.Sprintf("Player %s is now it", body.TargetId))
+}
+
+func (s *Server) handleUnfreeze(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var body UnfreezeRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid unfreeze request")))
+	}
+
+	playerId, _, err := s.authenticate(ctx, request)
+	if err != nil {
+		return writeError(err)
+	}
+	body.PlayerId = playerId
+
+	if err := s.unfreeze(ctx, body.GameId, body.PlayerId, body.TargetId); err != nil {
+		return writeError(httpErr(classify(err), fmt.Errorf("unfreeze failed: %w", err)))
+	}
+
+	return writeText(200, fmt.Sprintf("Player unfrozen: %s", body.TargetId))
+}
+
+// handleTickGame is invoked by the EventBridge rule scheduled for a timed
+// round's end, not by a player, so unlike every other handler it checks the
+// shared tick-game secret rather than a player's session.
+func (s *Server) handleTickGame(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if s.tickGameSecret != "" {
+		secret := request.Headers["X-Tick-Game-Secret"]
+		if secret == "" {
+			secret = request.Headers["x-tick-game-secret"]
+		}
+		if subtle.ConstantTimeCompare([]byte(secret), []byte(s.tickGameSecret)) != 1 {
+			return writeError(httpErr(401, errors.New("invalid tick game secret")))
+		}
+	}
+
+	var body TickGameRequest
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return writeError(httpErr(400, errors.New("invalid tick game request")))
+	}
+
+	if err := s.tickGame(ctx, body.GameId); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return writeError(httpErr(404, fmt.Errorf("game not found: %w", err)))
+		}
+		return writeError(httpErr(500, fmt.Errorf("tick game failed: %w", err)))
+	}
+
+	return writeText(200, fmt.Sprintf("Game ticked: %s", body.GameId))
+}
+
+func (s *Server) handleLogout(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if err := s.deleteSession(ctx, request); err != nil {
+		return writeError(httpErr(401, fmt.Errorf("logout failed: %w", err)))
+	}
+
+	return events.APIGatewayProxyResponse{StatusCode: 200, Body: "Logged out"}, nil
+}
@@ -0,0 +1,309 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/ArchieAdams/tag-game/internal/game"
+	"github.com/ArchieAdams/tag-game/internal/store"
+)
+
+// newTestServer returns a Server backed by an empty Fake, with no
+// broadcaster, scheduler, or tick-game secret - the same zero-dependency
+// shape main.go would build for local/test use.
+func newTestServer() (*Server, *store.Fake) {
+	fake := store.NewFake()
+	return New(fake, nil, nil, ""), fake
+}
+
+func bearerRequest(body, token string) events.APIGatewayWebsocketProxyRequest {
+	req := events.APIGatewayWebsocketProxyRequest{Body: body}
+	if token != "" {
+		req.Headers = map[string]string{"Authorization": "Bearer " + token}
+	}
+	return req
+}
+
+func authedToken(t *testing.T, s *Server, playerId, gameId string) string {
+	t.Helper()
+	token, err := s.createSession(context.Background(), playerId, gameId)
+	if err != nil {
+		t.Fatalf("createSession: %v", err)
+	}
+	return token
+}
+
+// TestHandleDeleteGame_NonOwnerGetsForbidden guards against handlers wrapping
+// an action error in a hardcoded status code instead of routing it through
+// classify - a non-owner's delete attempt must come back 403, not 400.
+func TestHandleDeleteGame_NonOwnerGetsForbidden(t *testing.T) {
+	s, fake := newTestServer()
+	ctx := context.Background()
+
+	if err := fake.CreateGameAndPlayer(ctx, game.Game{GameId: "g1", OwnerId: "owner"}, game.Player{PlayerId: "owner", GameId: "g1"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if err := fake.CreatePlayer(ctx, game.Player{PlayerId: "intruder", GameId: "g1"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	token := authedToken(t, s, "intruder", "g1")
+	body, _ := json.Marshal(GameRequest{GameId: "g1"})
+	resp, err := s.handleDeleteGame(ctx, bearerRequest(string(body), token))
+	if err != nil {
+		t.Fatalf("handleDeleteGame: %v", err)
+	}
+	if resp.StatusCode != 403 {
+		t.Errorf("status = %d, want 403", resp.StatusCode)
+	}
+}
+
+// TestHandleStartGame_NotEnoughPlayersGetsConflict exercises the same
+// classify-routing requirement for a different sentinel error.
+func TestHandleStartGame_NotEnoughPlayersGetsConflict(t *testing.T) {
+	s, fake := newTestServer()
+	ctx := context.Background()
+
+	settings := game.Settings{MinPlayersToStart: 2}.WithDefaults()
+	if err := fake.CreateGameAndPlayer(ctx, game.Game{GameId: "g1", OwnerId: "owner", Settings: settings}, game.Player{PlayerId: "owner", GameId: "g1"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	token := authedToken(t, s, "owner", "g1")
+	body, _ := json.Marshal(GameRequest{GameId: "g1"})
+	resp, err := s.handleStartGame(ctx, bearerRequest(string(body), token))
+	if err != nil {
+		t.Fatalf("handleStartGame: %v", err)
+	}
+	if resp.StatusCode != 409 {
+		t.Errorf("status = %d, want 409", resp.StatusCode)
+	}
+}
+
+func archiveListRequest(ownerId, token string) events.APIGatewayWebsocketProxyRequest {
+	req := events.APIGatewayWebsocketProxyRequest{
+		QueryStringParameters: map[string]string{"ownerId": ownerId},
+	}
+	if token != "" {
+		req.Headers = map[string]string{"Authorization": "Bearer " + token}
+	}
+	return req
+}
+
+// TestHandleArchiveList_RequiresAuthentication guards against the ownerId
+// query parameter being trusted outright: an unauthenticated request must be
+// rejected rather than returning that owner's archived games.
+func TestHandleArchiveList_RequiresAuthentication(t *testing.T) {
+	s, _ := newTestServer()
+	resp, err := s.handleArchiveList(context.Background(), archiveListRequest("victim", ""))
+	if err != nil {
+		t.Fatalf("handleArchiveList: %v", err)
+	}
+	if resp.StatusCode != 401 {
+		t.Errorf("status = %d, want 401", resp.StatusCode)
+	}
+}
+
+// TestHandleArchiveList_RejectsMismatchedOwner guards against an
+// authenticated caller reading a different owner's archived games.
+func TestHandleArchiveList_RejectsMismatchedOwner(t *testing.T) {
+	s, fake := newTestServer()
+	ctx := context.Background()
+	if err := fake.ArchiveGame(ctx, game.ArchivedGame{GameId: "g1", OwnerId: "victim"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	token := authedToken(t, s, "attacker", "")
+	resp, err := s.handleArchiveList(ctx, archiveListRequest("victim", token))
+	if err != nil {
+		t.Fatalf("handleArchiveList: %v", err)
+	}
+	if resp.StatusCode != 403 {
+		t.Errorf("status = %d, want 403", resp.StatusCode)
+	}
+}
+
+// TestHandleArchiveList_OwnerSeesOwnGames is the happy path alongside the two
+// rejection cases above.
+func TestHandleArchiveList_OwnerSeesOwnGames(t *testing.T) {
+	s, fake := newTestServer()
+	ctx := context.Background()
+	if err := fake.ArchiveGame(ctx, game.ArchivedGame{GameId: "g1", OwnerId: "owner"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	token := authedToken(t, s, "owner", "")
+	resp, err := s.handleArchiveList(ctx, archiveListRequest("owner", token))
+	if err != nil {
+		t.Fatalf("handleArchiveList: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+}
+
+// TestLeaveGame_AllowRejoinFalse_BlocksRejoin verifies Settings.AllowRejoin is
+// actually enforced: a player who leaves a no-rejoin game can't join it again
+// under the same ID, while a different ID still can.
+func TestLeaveGame_AllowRejoinFalse_BlocksRejoin(t *testing.T) {
+	s, fake := newTestServer()
+	ctx := context.Background()
+
+	settings := game.Settings{AllowRejoin: false}.WithDefaults()
+	if err := fake.CreateGameAndPlayer(ctx, game.Game{GameId: "g1", OwnerId: "owner", Settings: settings}, game.Player{PlayerId: "owner", GameId: "g1"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if err := fake.CreatePlayer(ctx, game.Player{PlayerId: "p1", GameId: "g1"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	if err := s.leaveGame(ctx, "p1"); err != nil {
+		t.Fatalf("leaveGame: %v", err)
+	}
+
+	if err := s.createPlayer(ctx, "g1", "p1", "P One"); err == nil {
+		t.Error("createPlayer: rejoin under the same ID should have been rejected")
+	} else if !errors.Is(err, game.ErrRejoinNotAllowed) {
+		t.Errorf("createPlayer error = %v, want ErrRejoinNotAllowed", err)
+	}
+
+	if err := s.createPlayer(ctx, "g1", "p2", "P Two"); err != nil {
+		t.Errorf("createPlayer for a fresh ID should still succeed, got: %v", err)
+	}
+}
+
+// TestLeaveGame_AllowRejoinTrue_PermitsRejoin is the contrasting case: when
+// AllowRejoin is true, leaveGame hard-deletes the row instead of tombstoning
+// it, so the same ID can join again.
+func TestLeaveGame_AllowRejoinTrue_PermitsRejoin(t *testing.T) {
+	s, fake := newTestServer()
+	ctx := context.Background()
+
+	settings := game.Settings{AllowRejoin: true}.WithDefaults()
+	if err := fake.CreateGameAndPlayer(ctx, game.Game{GameId: "g1", OwnerId: "owner", Settings: settings}, game.Player{PlayerId: "owner", GameId: "g1"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if err := fake.CreatePlayer(ctx, game.Player{PlayerId: "p1", GameId: "g1"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	if err := s.leaveGame(ctx, "p1"); err != nil {
+		t.Fatalf("leaveGame: %v", err)
+	}
+
+	if err := s.createPlayer(ctx, "g1", "p1", "P One"); err != nil {
+		t.Errorf("createPlayer: rejoin should be permitted when AllowRejoin is true, got: %v", err)
+	}
+}
+
+// TestLeaveGame_AllowRejoinFalse_StillPermitsJoiningADifferentGame guards
+// against the tombstone left by a no-rejoin game leaking into an unrelated
+// game: playerId is keyed globally in the store, so a player who's blocked
+// from rejoining game A must still be free to join a different game B.
+func TestLeaveGame_AllowRejoinFalse_StillPermitsJoiningADifferentGame(t *testing.T) {
+	s, fake := newTestServer()
+	ctx := context.Background()
+
+	settings := game.Settings{AllowRejoin: false}.WithDefaults()
+	if err := fake.CreateGameAndPlayer(ctx, game.Game{GameId: "g1", OwnerId: "owner1", Settings: settings}, game.Player{PlayerId: "owner1", GameId: "g1"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if err := fake.CreateGameAndPlayer(ctx, game.Game{GameId: "g2", OwnerId: "owner2", Settings: settings}, game.Player{PlayerId: "owner2", GameId: "g2"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if err := fake.CreatePlayer(ctx, game.Player{PlayerId: "p1", GameId: "g1"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	if err := s.leaveGame(ctx, "p1"); err != nil {
+		t.Fatalf("leaveGame: %v", err)
+	}
+
+	if err := s.createPlayer(ctx, "g2", "p1", "P One"); err != nil {
+		t.Errorf("createPlayer: joining a different game under the same ID should succeed, got: %v", err)
+	}
+}
+
+// TestHandleTag_DifferentGameTargetGetsConflict guards against the five
+// "player X is not in game Y" checks in actions.go returning a bare error
+// that classify can't map, which fell through to a 500 instead of the 409
+// ValidateTag already uses for game.ErrDifferentGame.
+func TestHandleTag_DifferentGameTargetGetsConflict(t *testing.T) {
+	s, fake := newTestServer()
+	ctx := context.Background()
+
+	if err := fake.CreateGameAndPlayer(ctx, game.Game{GameId: "g1", OwnerId: "owner"}, game.Player{PlayerId: "owner", GameId: "g1"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if err := fake.CreatePlayer(ctx, game.Player{PlayerId: "tagger", GameId: "g1", Alive: true, IsIt: true}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if err := fake.CreatePlayer(ctx, game.Player{PlayerId: "target", GameId: "g2", Alive: true}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	token := authedToken(t, s, "tagger", "g1")
+	body, _ := json.Marshal(TagRequest{GameRequest: GameRequest{GameId: "g1"}, TargetId: "target"})
+	resp, err := s.handleTag(ctx, bearerRequest(string(body), token))
+	if err != nil {
+		t.Fatalf("handleTag: %v", err)
+	}
+	if resp.StatusCode != 409 {
+		t.Errorf("status = %d, want 409, body = %s", resp.StatusCode, resp.Body)
+	}
+}
+
+// TestHandleSetIt_DifferentGameTargetGetsConflict covers the same bug class
+// for /setIt, whose "target not in game" check used to be a bare error.
+func TestHandleSetIt_DifferentGameTargetGetsConflict(t *testing.T) {
+	s, fake := newTestServer()
+	ctx := context.Background()
+
+	if err := fake.CreateGameAndPlayer(ctx, game.Game{GameId: "g1", OwnerId: "owner"}, game.Player{PlayerId: "owner", GameId: "g1"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if err := fake.CreatePlayer(ctx, game.Player{PlayerId: "target", GameId: "g2"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	token := authedToken(t, s, "owner", "g1")
+	body, _ := json.Marshal(SetItRequest{GameRequest: GameRequest{GameId: "g1"}, TargetId: "target"})
+	resp, err := s.handleSetIt(ctx, bearerRequest(string(body), token))
+	if err != nil {
+		t.Fatalf("handleSetIt: %v", err)
+	}
+	if resp.StatusCode != 409 {
+		t.Errorf("status = %d, want 409, body = %s", resp.StatusCode, resp.Body)
+	}
+}
+
+// TestHandleUnfreeze_DifferentGameTargetGetsConflict covers the same bug
+// class for /unfreeze's two "not in game" checks.
+func TestHandleUnfreeze_DifferentGameTargetGetsConflict(t *testing.T) {
+	s, fake := newTestServer()
+	ctx := context.Background()
+
+	if err := fake.CreateGameAndPlayer(ctx, game.Game{GameId: "g1", OwnerId: "owner"}, game.Player{PlayerId: "owner", GameId: "g1"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if err := fake.CreatePlayer(ctx, game.Player{PlayerId: "freer", GameId: "g1"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if err := fake.CreatePlayer(ctx, game.Player{PlayerId: "target", GameId: "g2", Frozen: true}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	token := authedToken(t, s, "freer", "g1")
+	body, _ := json.Marshal(UnfreezeRequest{GameRequest: GameRequest{GameId: "g1"}, TargetId: "target"})
+	resp, err := s.handleUnfreeze(ctx, bearerRequest(string(body), token))
+	if err != nil {
+		t.Fatalf("handleUnfreeze: %v", err)
+	}
+	if resp.StatusCode != 409 {
+		t.Errorf("status = %d, want 409, body = %s", resp.StatusCode, resp.Body)
+	}
+}
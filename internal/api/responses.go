@@ -0,0 +1,32 @@
+package api
+
+import (
+	"encoding/json"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// writeJSON marshals v as the response body, or falls back to writeError if
+// it can't be marshaled.
+func writeJSON(status int, v any) (events.APIGatewayProxyResponse, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return writeError(httpErr(500, err))
+	}
+	return events.APIGatewayProxyResponse{
+		StatusCode: status,
+		Body:       string(body),
+		Headers:    map[string]string{"Content-Type": "application/json"},
+	}, nil
+}
+
+// writeText returns a plain-text response body. The Content-Type header is
+// set to application/json for consistency with this API's JSON responses,
+// even though the body itself is a plain message string.
+func writeText(status int, body string) (events.APIGatewayProxyResponse, error) {
+	return events.APIGatewayProxyResponse{
+		StatusCode: status,
+		Body:       body,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+	}, nil
+}
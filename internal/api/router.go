@@ -0,0 +1,34 @@
+package api
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// HandlerFunc handles a single registered route.
+type HandlerFunc func(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error)
+
+// router dispatches a request to the HandlerFunc registered for its path.
+type router struct {
+	routes map[string]HandlerFunc
+}
+
+func newRouter() *router {
+	return &router{routes: make(map[string]HandlerFunc)}
+}
+
+// Register wires handler up to be called for requests matching method and
+// path. All routes in this API are POST today; method is accepted to mirror
+// how they're defined in API Gateway, but dispatch keys off path alone.
+func (r *router) Register(method, path string, handler HandlerFunc) {
+	r.routes[path] = handler
+}
+
+func (r *router) dispatch(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	handler, ok := r.routes[request.Path]
+	if !ok {
+		return events.APIGatewayProxyResponse{StatusCode: 404, Body: "Route not found"}, nil
+	}
+	return handler(ctx, request)
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/ArchieAdams/tag-game/internal/schedule"
+	"github.com/ArchieAdams/tag-game/internal/store"
+	"github.com/ArchieAdams/tag-game/internal/ws"
+)
+
+// Server holds the persistence layer and dispatches Lambda requests to the
+// handler registered for their path, or, for WebSocket invocations, to the
+// handler registered for their route key.
+type Server struct {
+	store       store.Store
+	router      *router
+	broadcaster *ws.Broadcaster
+	scheduler   *schedule.Scheduler
+	// tickGameSecret, if set, is the shared secret /tickGame requires on the
+	// X-Tick-Game-Secret header, since that route has no player to
+	// authenticate as a session - only the EventBridge rule that calls it.
+	tickGameSecret string
+}
+
+// New builds a Server backed by st and registers all of the API's routes.
+// broadcaster and scheduler may be nil, in which case game events simply
+// aren't pushed to any WebSocket clients, and timed rounds simply aren't
+// scheduled to end automatically. tickGameSecret may be empty in local/test
+// environments, in which case /tickGame accepts any caller.
+func New(st store.Store, broadcaster *ws.Broadcaster, scheduler *schedule.Scheduler, tickGameSecret string) *Server {
+	s := &Server{store: st, router: newRouter(), broadcaster: broadcaster, scheduler: scheduler, tickGameSecret: tickGameSecret}
+	s.registerRoutes()
+	return s
+}
+
+// HandleRequest is the Lambda entry point. The REST and WebSocket APIs share
+// this one Lambda, so a single request type covers both invocation shapes;
+// RequestContext.EventType is only populated for WebSocket events, which is
+// what tells the two apart.
+func (s *Server) HandleRequest(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if request.RequestContext.EventType != "" {
+		return s.dispatchWebSocket(ctx, request)
+	}
+	return s.router.dispatch(ctx, request)
+}
+
+// broadcast pushes event to every client connected to gameId, if this Server
+// was built with a broadcaster.
+func (s *Server) broadcast(ctx context.Context, gameId string, event any) {
+	if s.broadcaster == nil {
+		return
+	}
+	s.broadcaster.Broadcast(ctx, gameId, event)
+}
+
+func (s *Server) registerRoutes() {
+	r := s.router
+	r.Register("POST", "/createGame", s.handleCreateGame)
+	r.Register("POST", "/joinGame", s.handleJoinGame)
+	r.Register("POST", "/deleteGame", s.handleDeleteGame)
+	r.Register("POST", "/removePlayer", s.handleRemovePlayer)
+	r.Register("POST", "/startGame", s.handleStartGame)
+	r.Register("POST", "/endGame", s.handleEndGame)
+	r.Register("POST", "/playerList", s.handlePlayerList)
+	r.Register("POST", "/leaveGame", s.handleLeaveGame)
+	r.Register("POST", "/gameState", s.handleGameState)
+	r.Register("POST", "/isOwner", s.handleIsOwner)
+	r.Register("POST", "/tag", s.handleTag)
+	r.Register("POST", "/getItPlayer", s.handleGetItPlayer)
+	r.Register("POST", "/eliminate", s.handleEliminate)
+	r.Register("POST", "/setIt", s.handleSetIt)
+	r.Register("POST", "/unfreeze", s.handleUnfreeze)
+	r.Register("POST", "/tickGame", s.handleTickGame)
+	r.Register("POST", "/logout", s.handleLogout)
+
+	r.Register("GET", "/archive/game", s.handleArchiveGame)
+	r.Register("GET", "/archive/list", s.handleArchiveList)
+	r.Register("GET", "/leaderboard/global", s.handleLeaderboardGlobal)
+	r.Register("GET", "/leaderboard/rankingPageCount", s.handleRankingPageCount)
+}
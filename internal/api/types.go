@@ -0,0 +1,55 @@
+package api
+
+import "github.com/ArchieAdams/tag-game/internal/game"
+
+// GameRequest carries the fields common to most game-scoped request bodies.
+// PlayerId is overwritten with the authenticated caller's ID before a
+// handler acts on it; a body-provided value is never trusted.
+type GameRequest struct {
+	GameId   string `json:"gameId"`
+	PlayerId string `json:"playerId"`
+}
+
+type CreateGameRequest struct {
+	GameName   string        `json:"gameName"`
+	PlayerName string        `json:"playerName"`
+	Settings   game.Settings `json:"settings"`
+	GameRequest
+}
+
+type JoinGameRequest struct {
+	GameRequest
+	PlayerName string `json:"playerName"`
+}
+
+type RemovePlayerRequest struct {
+	GameRequest
+	PlayerIdToRemove string `json:"playerIdToRemove"`
+}
+
+type TagRequest struct {
+	GameRequest
+	TargetId string `json:"targetId"`
+}
+
+type EliminateRequest struct {
+	GameRequest
+	TargetId string `json:"targetId"`
+}
+
+type SetItRequest struct {
+	GameRequest
+	TargetId string `json:"targetId"`
+}
+
+type UnfreezeRequest struct {
+	GameRequest
+	TargetId string `json:"targetId"`
+}
+
+// TickGameRequest is posted by the EventBridge rule scheduled for a timed
+// round's end; it carries no authentication, since only /tickGame's own
+// infrastructure can target it.
+type TickGameRequest struct {
+	GameId string `json:"gameId"`
+}
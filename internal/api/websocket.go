@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/ArchieAdams/tag-game/internal/game"
+	"github.com/ArchieAdams/tag-game/internal/store"
+)
+
+// dispatchWebSocket routes a WebSocket Lambda invocation by its route key.
+func (s *Server) dispatchWebSocket(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	switch request.RequestContext.RouteKey {
+	case "$connect":
+		return s.handleConnect(ctx, request)
+	case "$disconnect":
+		return s.handleDisconnect(ctx, request)
+	default:
+		return s.handleDefault(ctx, request)
+	}
+}
+
+// handleConnect authenticates the connecting client against its session
+// token and subscribes its connection to the game the token was issued for.
+func (s *Server) handleConnect(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	token := request.QueryStringParameters["token"]
+	gameId := request.QueryStringParameters["gameId"]
+	if token == "" || gameId == "" {
+		return events.APIGatewayProxyResponse{StatusCode: 400, Body: "token and gameId query parameters are required"}, nil
+	}
+
+	sess, err := s.store.GetSession(ctx, hashSessionToken(token))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return events.APIGatewayProxyResponse{StatusCode: 401, Body: "invalid or expired session"}, nil
+		}
+		return events.APIGatewayProxyResponse{StatusCode: 500, Body: "failed to look up session"}, nil
+	}
+	if time.Now().Unix() > sess.ExpiresAt || sess.GameId != gameId {
+		return events.APIGatewayProxyResponse{StatusCode: 401, Body: "invalid or expired session"}, nil
+	}
+
+	conn := game.Connection{
+		GameId:       gameId,
+		ConnectionId: request.RequestContext.ConnectionID,
+		PlayerId:     sess.PlayerId,
+		ConnectedAt:  request.RequestContext.ConnectedAt,
+	}
+	if err := s.store.PutConnection(ctx, conn); err != nil {
+		return events.APIGatewayProxyResponse{StatusCode: 500, Body: "failed to save connection"}, nil
+	}
+
+	return events.APIGatewayProxyResponse{StatusCode: 200, Body: "Connected"}, nil
+}
+
+// handleDisconnect removes the closed connection and drops its player from
+// the game, so a client that never calls /leaveGame still disappears from
+// the player list.
+func (s *Server) handleDisconnect(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	connectionId := request.RequestContext.ConnectionID
+
+	conn, err := s.store.GetConnectionByConnectionId(ctx, connectionId)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return events.APIGatewayProxyResponse{StatusCode: 200, Body: "Disconnected"}, nil
+		}
+		return events.APIGatewayProxyResponse{StatusCode: 500, Body: "failed to look up connection"}, nil
+	}
+
+	if err := s.store.DeleteConnection(ctx, conn.GameId, conn.ConnectionId); err != nil {
+		return events.APIGatewayProxyResponse{StatusCode: 500, Body: "failed to delete connection"}, nil
+	}
+
+	if err := s.leaveGame(ctx, conn.PlayerId); err != nil {
+		log.Printf("failed to leave game for disconnected player %s: %v", conn.PlayerId, err)
+	}
+
+	return events.APIGatewayProxyResponse{StatusCode: 200, Body: "Disconnected"}, nil
+}
+
+// handleDefault handles the $default route. Clients only ever receive
+// events on this API today, so there's nothing for an inbound message to
+// trigger; it's acked and otherwise ignored.
+func (s *Server) handleDefault(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return events.APIGatewayProxyResponse{StatusCode: 200, Body: "OK"}, nil
+}
@@ -0,0 +1,76 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ArchivedGame is an immutable snapshot of a game and its players' final
+// stats, taken the moment the game ends.
+type ArchivedGame struct {
+	GameId   string                `dynamodbav:"gameId" json:"gameId"`
+	GameName string                `dynamodbav:"gameName" json:"gameName"`
+	OwnerId  string                `dynamodbav:"ownerId" json:"ownerId"`
+	EndedAt  int64                 `dynamodbav:"endedAt" json:"endedAt"`
+	Players  []ArchivedPlayerStats `dynamodbav:"players" json:"players"`
+}
+
+// ArchivedPlayerStats is one player's final standing in an ArchivedGame.
+type ArchivedPlayerStats struct {
+	PlayerId    string `dynamodbav:"playerId" json:"playerId"`
+	PlayerName  string `dynamodbav:"playerName" json:"playerName"`
+	Alive       bool   `dynamodbav:"alive" json:"alive"`
+	WasLastIt   bool   `dynamodbav:"wasLastIt" json:"wasLastIt"`
+	TagsMade    int    `dynamodbav:"tagsMade" json:"tagsMade"`
+	TimesTagged int    `dynamodbav:"timesTagged" json:"timesTagged"`
+	SurvivedMs  int64  `dynamodbav:"survivedMs" json:"survivedMs"`
+}
+
+// PlayerStats aggregates a player's performance across every game archived
+// within a single leaderboard window bucket (see WindowBucket).
+type PlayerStats struct {
+	Window      string `dynamodbav:"window" json:"window"`
+	PlayerId    string `dynamodbav:"playerId" json:"playerId"`
+	PlayerName  string `dynamodbav:"playerName" json:"playerName"`
+	TotalTags   int    `dynamodbav:"totalTags" json:"totalTags"`
+	GamesPlayed int    `dynamodbav:"gamesPlayed" json:"gamesPlayed"`
+	Wins        int    `dynamodbav:"wins" json:"wins"`
+}
+
+// LeaderboardWindow selects how PlayerStats rows are bucketed for ranking.
+type LeaderboardWindow string
+
+const (
+	WindowDaily   LeaderboardWindow = "daily"
+	WindowWeekly  LeaderboardWindow = "weekly"
+	WindowAllTime LeaderboardWindow = "alltime"
+)
+
+// ErrInvalidWindow is returned for a LeaderboardWindow value that isn't one
+// of WindowDaily, WindowWeekly, or WindowAllTime.
+var ErrInvalidWindow = errors.New("invalid leaderboard window")
+
+// WindowBucket returns the stable key PlayerStats rows for window are
+// grouped under at time at, e.g. "daily#2026-07-29" or "weekly#2026-W31".
+// alltime has a single, constant bucket.
+func WindowBucket(window LeaderboardWindow, at time.Time) (string, error) {
+	at = at.UTC()
+	switch window {
+	case WindowDaily:
+		return "daily#" + at.Format("2006-01-02"), nil
+	case WindowWeekly:
+		year, week := at.ISOWeek()
+		return fmt.Sprintf("weekly#%04d-W%02d", year, week), nil
+	case WindowAllTime:
+		return "alltime", nil
+	default:
+		return "", ErrInvalidWindow
+	}
+}
+
+// Won reports whether a player's final standing counts as a win: they
+// survived to the end of the game without being the player left "it".
+func (p ArchivedPlayerStats) Won() bool {
+	return p.Alive && !p.WasLastIt
+}
@@ -0,0 +1,105 @@
+// Package game holds the domain types for tag-game and the pure business
+// rules that govern them. Nothing in this package talks to DynamoDB or API
+// Gateway, so the rules here can be unit tested on their own.
+package game
+
+import "errors"
+
+// Game is a single match: a name, its owner, the rules it plays by, and
+// whether it has started.
+type Game struct {
+	GameId         string   `dynamodbav:"gameId" json:"gameId"`
+	GameName       string   `dynamodbav:"gameName" json:"gameName"`
+	HasGameStarted bool     `dynamodbav:"hasGameStarted" json:"hasGameStarted"`
+	OwnerId        string   `dynamodbav:"ownerId" json:"ownerId"`
+	Settings       Settings `dynamodbav:"settings" json:"settings"`
+	// StartedAt is set when the game transitions to started, and is used to
+	// compute how long players survived once the game is archived.
+	StartedAt int64 `dynamodbav:"startedAt" json:"startedAt"`
+	// EndsAt is the round's scheduled end time, set alongside StartedAt when
+	// Settings.RoundSeconds is positive. Zero means no timer is armed.
+	EndsAt int64 `dynamodbav:"endsAt" json:"endsAt"`
+}
+
+// Player is a participant in a Game and their current gameplay state.
+type Player struct {
+	PlayerId     string `dynamodbav:"playerId" json:"playerId"`
+	PlayerName   string `dynamodbav:"playerName" json:"playerName"`
+	GameId       string `dynamodbav:"gameId" json:"gameId"`
+	IsIt         bool   `dynamodbav:"isIt" json:"isIt"`
+	Alive        bool   `dynamodbav:"alive" json:"alive"`
+	TagsMade     int    `dynamodbav:"tagsMade" json:"tagsMade"`
+	TimesTagged  int    `dynamodbav:"timesTagged" json:"timesTagged"`
+	LastTaggedAt int64  `dynamodbav:"lastTaggedAt" json:"lastTaggedAt"`
+	// Frozen is set in freeze mode when a player is tagged; they can't tag
+	// or be tagged again until an unfrozen teammate frees them via /unfreeze.
+	Frozen bool `dynamodbav:"frozen" json:"frozen"`
+	// Left is set instead of deleting the row when a player leaves a game
+	// whose Settings.AllowRejoin is false, so a later rejoin attempt under
+	// the same ID keeps getting rejected. Players with Left set are excluded
+	// from PlayersByGame.
+	Left bool `dynamodbav:"left" json:"left"`
+}
+
+// TagEvent records a single tag as it happened.
+type TagEvent struct {
+	GameId    string `dynamodbav:"gameId" json:"gameId"`
+	EventId   string `dynamodbav:"eventId" json:"eventId"`
+	TaggerId  string `dynamodbav:"taggerId" json:"taggerId"`
+	TargetId  string `dynamodbav:"targetId" json:"targetId"`
+	CreatedAt int64  `dynamodbav:"createdAt" json:"createdAt"`
+}
+
+// Session maps an issued bearer token to the player and game it authenticates.
+type Session struct {
+	TokenHash string `dynamodbav:"tokenHash" json:"tokenHash"`
+	PlayerId  string `dynamodbav:"playerId" json:"playerId"`
+	GameId    string `dynamodbav:"gameId" json:"gameId"`
+	ExpiresAt int64  `dynamodbav:"expiresAt" json:"expiresAt"`
+}
+
+// Connection is an open API Gateway WebSocket connection subscribed to a
+// game's real-time events.
+type Connection struct {
+	GameId       string `dynamodbav:"gameId" json:"gameId"`
+	ConnectionId string `dynamodbav:"connectionId" json:"connectionId"`
+	PlayerId     string `dynamodbav:"playerId" json:"playerId"`
+	ConnectedAt  int64  `dynamodbav:"connectedAt" json:"connectedAt"`
+}
+
+var (
+	// ErrNotOwner is returned when a caller attempts an owner-only action.
+	ErrNotOwner = errors.New("only the game owner can perform this action")
+	// ErrNotIt is returned when a tag is attempted by a player who isn't "it".
+	ErrNotIt = errors.New("player is not it")
+	// ErrTargetNotAlive is returned when a tag targets an eliminated player.
+	ErrTargetNotAlive = errors.New("target player is not alive")
+	// ErrDifferentGame is returned when two players referenced together aren't in the same game.
+	ErrDifferentGame = errors.New("players are not in the same game")
+	// ErrNoPlayers is returned when an action needs at least one player in the game and finds none.
+	ErrNoPlayers = errors.New("game has no players")
+	// ErrNoItPlayer is returned when a game has no player currently marked "it".
+	ErrNoItPlayer = errors.New("no it player found for game")
+	// ErrNotParticipant is returned when a caller who was neither the owner
+	// nor a player of a game tries to view its archive.
+	ErrNotParticipant = errors.New("only the game's owner or players can view its archive")
+)
+
+// ValidateTag enforces the tag rules common to every mode: the tagger must
+// currently be "it", and the target must be alive, unfrozen, and in the
+// same game as the tagger.
+func ValidateTag(tagger, target Player) error {
+	if tagger.GameId != target.GameId {
+		return ErrDifferentGame
+	}
+	if !tagger.IsIt {
+		return ErrNotIt
+	}
+	if !target.Alive {
+		return ErrTargetNotAlive
+	}
+	if target.Frozen {
+		return ErrAlreadyFrozen
+	}
+	return nil
+}
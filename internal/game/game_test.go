@@ -0,0 +1,51 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestWireFormat locks in the camelCase JSON field names the API has always
+// used, so a future refactor can't silently drop or rename a json tag the
+// way the internal/api package split once did.
+func TestWireFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		want map[string]bool
+	}{
+		{
+			name: "Game",
+			v:    Game{GameId: "g1", GameName: "Tag", OwnerId: "p1"},
+			want: map[string]bool{"gameId": true, "gameName": true, "hasGameStarted": true, "ownerId": true, "settings": true, "startedAt": true, "endsAt": true},
+		},
+		{
+			name: "Player",
+			v:    Player{PlayerId: "p1", PlayerName: "Alice", GameId: "g1"},
+			want: map[string]bool{"playerId": true, "playerName": true, "gameId": true, "isIt": true, "alive": true, "tagsMade": true, "timesTagged": true, "lastTaggedAt": true, "frozen": true, "left": true},
+		},
+		{
+			name: "TagEvent",
+			v:    TagEvent{GameId: "g1", EventId: "e1", TaggerId: "p1", TargetId: "p2"},
+			want: map[string]bool{"gameId": true, "eventId": true, "taggerId": true, "targetId": true, "createdAt": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(tt.v)
+			if err != nil {
+				t.Fatalf("Marshal(%s): %v", tt.name, err)
+			}
+			var fields map[string]any
+			if err := json.Unmarshal(body, &fields); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			for key := range tt.want {
+				if _, ok := fields[key]; !ok {
+					t.Errorf("%s: missing expected camelCase field %q in %s", tt.name, key, body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package game
+
+import "errors"
+
+// GameMode selects which tag rules a Game plays by.
+type GameMode string
+
+const (
+	// ModeClassic passes "it" from tagger to target on every tag.
+	ModeClassic GameMode = "classic"
+	// ModeFreeze freezes a tagged player in place instead of passing "it";
+	// an unfrozen teammate can free them again with /unfreeze.
+	ModeFreeze GameMode = "freeze"
+	// ModeDeathmatch eliminates a tagged player; "it" never changes hands.
+	ModeDeathmatch GameMode = "deathmatch"
+)
+
+// Settings configures the rules a Game plays by, set once at creation time.
+type Settings struct {
+	Mode GameMode `dynamodbav:"mode" json:"mode"`
+	// MaxPlayers caps how many players may join the game. Zero means
+	// unlimited.
+	MaxPlayers int `dynamodbav:"maxPlayers" json:"maxPlayers"`
+	// RoundSeconds, if positive, ends the game automatically that many
+	// seconds after it starts.
+	RoundSeconds int  `dynamodbav:"roundSeconds" json:"roundSeconds"`
+	AllowRejoin  bool `dynamodbav:"allowRejoin" json:"allowRejoin"`
+	// MinPlayersToStart is the fewest players StartGame will accept.
+	MinPlayersToStart int `dynamodbav:"minPlayersToStart" json:"minPlayersToStart"`
+}
+
+var (
+	// ErrInvalidMode is returned for a GameMode value that isn't one of
+	// ModeClassic, ModeFreeze, or ModeDeathmatch.
+	ErrInvalidMode = errors.New("invalid game mode")
+	// ErrGameFull is returned when JoinGame would exceed Settings.MaxPlayers.
+	ErrGameFull = errors.New("game has reached its maximum number of players")
+	// ErrNotEnoughPlayers is returned when StartGame is called with fewer
+	// players than Settings.MinPlayersToStart.
+	ErrNotEnoughPlayers = errors.New("not enough players to start the game")
+	// ErrNotFrozen is returned when /unfreeze targets a player who isn't frozen.
+	ErrNotFrozen = errors.New("target player is not frozen")
+	// ErrAlreadyFrozen is returned when a frozen player tries to tag or
+	// unfreeze someone else while still frozen themselves.
+	ErrAlreadyFrozen = errors.New("player is frozen")
+	// ErrRejoinNotAllowed is returned when a player who already left a game
+	// with Settings.AllowRejoin false tries to join it again.
+	ErrRejoinNotAllowed = errors.New("rejoining this game is not allowed")
+)
+
+// ValidateMode reports ErrInvalidMode unless mode is one of the supported
+// GameModes.
+func ValidateMode(mode GameMode) error {
+	switch mode {
+	case ModeClassic, ModeFreeze, ModeDeathmatch:
+		return nil
+	default:
+		return ErrInvalidMode
+	}
+}
+
+// WithDefaults fills in the fields a caller is allowed to leave unset:
+// Mode defaults to classic, and MinPlayersToStart defaults to 1. A zero
+// MaxPlayers or RoundSeconds already means "unlimited"/"no timer", so
+// those are left alone.
+func (s Settings) WithDefaults() Settings {
+	if s.Mode == "" {
+		s.Mode = ModeClassic
+	}
+	if s.MinPlayersToStart <= 0 {
+		s.MinPlayersToStart = 1
+	}
+	return s
+}
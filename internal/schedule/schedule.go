@@ -0,0 +1,89 @@
+// Package schedule arranges for a one-shot EventBridge rule to call this
+// API's /tickGame endpoint when a timed round's clock runs out.
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+)
+
+// Scheduler schedules and cancels the one-shot EventBridge rule that ends a
+// timed round.
+type Scheduler struct {
+	client    *eventbridge.EventBridge
+	targetArn string
+	roleArn   string
+}
+
+// NewScheduler returns a Scheduler whose one-shot rules invoke targetArn
+// (the API destination for this API's /tickGame route), assuming roleArn to
+// do so.
+func NewScheduler(targetArn, roleArn string) *Scheduler {
+	sess := session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+	return &Scheduler{client: eventbridge.New(sess), targetArn: targetArn, roleArn: roleArn}
+}
+
+func ruleName(gameId string) string {
+	return "tag-game-end-" + gameId
+}
+
+// ScheduleGameEnd arranges for /tickGame to be called with gameId once, at
+// endsAt.
+func (s *Scheduler) ScheduleGameEnd(ctx context.Context, gameId string, endsAt time.Time) error {
+	name := ruleName(gameId)
+
+	_, err := s.client.PutRuleWithContext(ctx, &eventbridge.PutRuleInput{
+		Name:               aws.String(name),
+		ScheduleExpression: aws.String(fmt.Sprintf("at(%s)", endsAt.UTC().Format("2006-01-02T15:04:05"))),
+		State:              aws.String(eventbridge.RuleStateEnabled),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule game end: %w", err)
+	}
+
+	_, err = s.client.PutTargetsWithContext(ctx, &eventbridge.PutTargetsInput{
+		Rule: aws.String(name),
+		Targets: []*eventbridge.Target{
+			{
+				Id:      aws.String("tickGame"),
+				Arn:     aws.String(s.targetArn),
+				RoleArn: aws.String(s.roleArn),
+				Input:   aws.String(fmt.Sprintf(`{"gameId":%q}`, gameId)),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to target game end rule: %w", err)
+	}
+
+	return nil
+}
+
+// CancelGameEnd removes the one-shot rule scheduled for gameId, if any. It's
+// safe to call for a game that was never scheduled or whose rule already
+// fired.
+func (s *Scheduler) CancelGameEnd(ctx context.Context, gameId string) error {
+	name := ruleName(gameId)
+
+	if _, err := s.client.RemoveTargetsWithContext(ctx, &eventbridge.RemoveTargetsInput{
+		Rule: aws.String(name),
+		Ids:  []*string{aws.String("tickGame")},
+	}); err != nil {
+		return fmt.Errorf("failed to remove game end targets: %w", err)
+	}
+
+	if _, err := s.client.DeleteRuleWithContext(ctx, &eventbridge.DeleteRuleInput{
+		Name: aws.String(name),
+	}); err != nil {
+		return fmt.Errorf("failed to delete game end rule: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+
+	"github.com/ArchieAdams/tag-game/internal/game"
+)
+
+// ownerIdIndex lists a game's archive by ownerId, newest first.
+const ownerIdIndex = "ownerIdIndex"
+
+// windowRankIndex lists player_stats rows within a window bucket ordered by
+// totalTags, for the global leaderboard.
+const windowRankIndex = "windowRankIndex"
+
+// encodeCursor turns a DynamoDB LastEvaluatedKey into the opaque cursor
+// string handed back to API callers.
+func encodeCursor(key map[string]*dynamodb.AttributeValue) (string, error) {
+	if len(key) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodeCursor reverses encodeCursor. An empty cursor decodes to a nil key,
+// which DynamoDB treats as "start from the beginning".
+func decodeCursor(cursor string) (map[string]*dynamodb.AttributeValue, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	var key map[string]*dynamodb.AttributeValue
+	if err := json.Unmarshal(b, &key); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return key, nil
+}
+
+// ArchiveGame persists the final snapshot of a finished game. It does not
+// touch the live Games/Players tables; DeleteGame remains the caller's way
+// to clean those up once the archive exists.
+func (d *DynamoStore) ArchiveGame(ctx context.Context, archived game.ArchivedGame) error {
+	av, err := dynamodbattribute.MarshalMap(archived)
+	if err != nil {
+		return fmt.Errorf("failed to marshal archived game: %w", err)
+	}
+
+	_, err = d.svc.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(d.tables.ArchivedGames),
+		Item:      av,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive game: %w", err)
+	}
+	return nil
+}
+
+func (d *DynamoStore) GetArchivedGame(ctx context.Context, gameId string) (*game.ArchivedGame, error) {
+	result, err := d.svc.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.tables.ArchivedGames),
+		Key: map[string]*dynamodb.AttributeValue{
+			"gameId": {S: aws.String(gameId)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived game: %w", err)
+	}
+	if result.Item == nil {
+		return nil, ErrNotFound
+	}
+
+	var archived game.ArchivedGame
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &archived); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal archived game: %w", err)
+	}
+	return &archived, nil
+}
+
+func (d *DynamoStore) ListArchivedGamesByOwner(ctx context.Context, ownerId string, limit int, cursor string) ([]game.ArchivedGame, string, error) {
+	startKey, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result, err := d.svc.QueryWithContext(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(d.tables.ArchivedGames),
+		IndexName:              aws.String(ownerIdIndex),
+		KeyConditionExpression: aws.String("ownerId = :oid"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":oid": {S: aws.String(ownerId)},
+		},
+		ScanIndexForward:  aws.Bool(false),
+		Limit:             aws.Int64(int64(limit)),
+		ExclusiveStartKey: startKey,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query archived games by owner: %w", err)
+	}
+
+	games := make([]game.ArchivedGame, 0, len(result.Items))
+	for _, item := range result.Items {
+		var archived game.ArchivedGame
+		if err := dynamodbattribute.UnmarshalMap(item, &archived); err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal archived game: %w", err)
+		}
+		games = append(games, archived)
+	}
+
+	nextCursor, err := encodeCursor(result.LastEvaluatedKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return games, nextCursor, nil
+}
+
+// IncrementPlayerStats upserts a player's row for window, adding tagsMade to
+// their running total and incrementing gamesPlayed (and wins, if win).
+func (d *DynamoStore) IncrementPlayerStats(ctx context.Context, window string, playerId, playerName string, tagsMade int, win bool) error {
+	winIncrement := 0
+	if win {
+		winIncrement = 1
+	}
+
+	_, err := d.svc.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(d.tables.PlayerStats),
+		Key: map[string]*dynamodb.AttributeValue{
+			"window":   {S: aws.String(window)},
+			"playerId": {S: aws.String(playerId)},
+		},
+		UpdateExpression: aws.String("SET playerName = :name ADD totalTags :tags, gamesPlayed :one, wins :wins"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":name": {S: aws.String(playerName)},
+			":tags": {N: aws.String(fmt.Sprintf("%d", tagsMade))},
+			":one":  {N: aws.String("1")},
+			":wins": {N: aws.String(fmt.Sprintf("%d", winIncrement))},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update player stats: %w", err)
+	}
+	return nil
+}
+
+func (d *DynamoStore) Leaderboard(ctx context.Context, window string, limit int, cursor string) ([]game.PlayerStats, string, error) {
+	startKey, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result, err := d.svc.QueryWithContext(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(d.tables.PlayerStats),
+		IndexName:              aws.String(windowRankIndex),
+		KeyConditionExpression: aws.String("#w = :window"),
+		ExpressionAttributeNames: map[string]*string{
+			"#w": aws.String("window"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":window": {S: aws.String(window)},
+		},
+		ScanIndexForward:  aws.Bool(false),
+		Limit:             aws.Int64(int64(limit)),
+		ExclusiveStartKey: startKey,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query leaderboard: %w", err)
+	}
+
+	entries := make([]game.PlayerStats, 0, len(result.Items))
+	for _, item := range result.Items {
+		var stats game.PlayerStats
+		if err := dynamodbattribute.UnmarshalMap(item, &stats); err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal player stats: %w", err)
+		}
+		entries = append(entries, stats)
+	}
+
+	nextCursor, err := encodeCursor(result.LastEvaluatedKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return entries, nextCursor, nil
+}
+
+func (d *DynamoStore) CountPlayerStats(ctx context.Context, window string) (int, error) {
+	result, err := d.svc.QueryWithContext(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(d.tables.PlayerStats),
+		IndexName:              aws.String(windowRankIndex),
+		KeyConditionExpression: aws.String("#w = :window"),
+		ExpressionAttributeNames: map[string]*string{
+			"#w": aws.String("window"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":window": {S: aws.String(window)},
+		},
+		Select: aws.String(dynamodb.SelectCount),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count player stats: %w", err)
+	}
+	return int(*result.Count), nil
+}
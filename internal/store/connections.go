@@ -0,0 +1,97 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+
+	"github.com/ArchieAdams/tag-game/internal/game"
+)
+
+// connectionIdIndex looks a connection up by connectionId alone, for
+// $disconnect events, which carry no gameId.
+const connectionIdIndex = "connectionIdIndex"
+
+// PutConnection records a newly opened WebSocket connection against the game
+// it subscribes to.
+func (d *DynamoStore) PutConnection(ctx context.Context, c game.Connection) error {
+	av, err := dynamodbattribute.MarshalMap(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal connection: %w", err)
+	}
+
+	_, err = d.svc.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(d.tables.Connections),
+		Item:      av,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put connection: %w", err)
+	}
+	return nil
+}
+
+func (d *DynamoStore) GetConnectionByConnectionId(ctx context.Context, connectionId string) (*game.Connection, error) {
+	result, err := d.svc.QueryWithContext(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(d.tables.Connections),
+		IndexName:              aws.String(connectionIdIndex),
+		KeyConditionExpression: aws.String("connectionId = :cid"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":cid": {S: aws.String(connectionId)},
+		},
+		Limit: aws.Int64(1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query connection: %w", err)
+	}
+	if len(result.Items) == 0 {
+		return nil, ErrNotFound
+	}
+
+	var c game.Connection
+	if err := dynamodbattribute.UnmarshalMap(result.Items[0], &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal connection: %w", err)
+	}
+	return &c, nil
+}
+
+func (d *DynamoStore) DeleteConnection(ctx context.Context, gameId, connectionId string) error {
+	_, err := d.svc.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(d.tables.Connections),
+		Key: map[string]*dynamodb.AttributeValue{
+			"gameId":       {S: aws.String(gameId)},
+			"connectionId": {S: aws.String(connectionId)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete connection: %w", err)
+	}
+	return nil
+}
+
+// ConnectionsByGame returns every connection currently subscribed to gameId,
+// for fanning a broadcast out to them.
+func (d *DynamoStore) ConnectionsByGame(ctx context.Context, gameId string) ([]game.Connection, error) {
+	result, err := d.svc.QueryWithContext(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(d.tables.Connections),
+		KeyConditionExpression: aws.String("gameId = :gid"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":gid": {S: aws.String(gameId)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query connections by gameId: %w", err)
+	}
+
+	conns := make([]game.Connection, 0, len(result.Items))
+	for _, item := range result.Items {
+		var c game.Connection
+		if err := dynamodbattribute.UnmarshalMap(item, &c); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal connection: %w", err)
+		}
+		conns = append(conns, c)
+	}
+	return conns, nil
+}
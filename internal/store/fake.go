@@ -0,0 +1,407 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/ArchieAdams/tag-game/internal/game"
+)
+
+// Fake is an in-memory Store for unit tests that exercise handlers without
+// hitting AWS. It ignores pagination cursors and always returns every
+// matching row in one page. Its methods are safe for concurrent use, since
+// the WebSocket broadcaster calls them from multiple goroutines at once.
+type Fake struct {
+	mu            sync.Mutex
+	Games         map[string]game.Game
+	Players       map[string]game.Player
+	Sessions      map[string]game.Session
+	ArchivedGames map[string]game.ArchivedGame
+	PlayerStats   map[string]game.PlayerStats
+	Connections   map[string]game.Connection
+	TagLog        []game.TagEvent
+}
+
+// NewFake returns an empty in-memory Store.
+func NewFake() *Fake {
+	return &Fake{
+		Games:         make(map[string]game.Game),
+		Players:       make(map[string]game.Player),
+		Sessions:      make(map[string]game.Session),
+		ArchivedGames: make(map[string]game.ArchivedGame),
+		PlayerStats:   make(map[string]game.PlayerStats),
+		Connections:   make(map[string]game.Connection),
+	}
+}
+
+func playerStatsKey(window, playerId string) string {
+	return window + "#" + playerId
+}
+
+func connectionKey(gameId, connectionId string) string {
+	return gameId + "#" + connectionId
+}
+
+func (f *Fake) CreateGameAndPlayer(ctx context.Context, g game.Game, p game.Player) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, exists := f.Games[g.GameId]; exists {
+		return ErrConflict
+	}
+	if _, exists := f.Players[p.PlayerId]; exists {
+		return ErrConflict
+	}
+	f.Games[g.GameId] = g
+	f.Players[p.PlayerId] = p
+	return nil
+}
+
+func (f *Fake) GetGame(ctx context.Context, gameId string) (*game.Game, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g, ok := f.Games[gameId]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &g, nil
+}
+
+func (f *Fake) DeleteGame(ctx context.Context, gameId string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.Games, gameId)
+	for id, p := range f.Players {
+		if p.GameId == gameId {
+			delete(f.Players, id)
+		}
+	}
+	return nil
+}
+
+func (f *Fake) StartGameState(ctx context.Context, gameId string, startedAt, endsAt int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g, ok := f.Games[gameId]
+	if !ok {
+		return ErrNotFound
+	}
+	g.HasGameStarted = true
+	g.StartedAt = startedAt
+	g.EndsAt = endsAt
+	f.Games[gameId] = g
+	return nil
+}
+
+func (f *Fake) SetGameState(ctx context.Context, gameId string, started bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g, ok := f.Games[gameId]
+	if !ok {
+		return ErrNotFound
+	}
+	g.HasGameStarted = started
+	f.Games[gameId] = g
+	return nil
+}
+
+func (f *Fake) CreatePlayer(ctx context.Context, p game.Player) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, exists := f.Players[p.PlayerId]; exists {
+		return ErrConflict
+	}
+	f.Players[p.PlayerId] = p
+	return nil
+}
+
+func (f *Fake) GetPlayer(ctx context.Context, playerId string) (*game.Player, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.Players[playerId]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &p, nil
+}
+
+func (f *Fake) DeletePlayer(ctx context.Context, playerId string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.Players, playerId)
+	return nil
+}
+
+func (f *Fake) MarkPlayerLeft(ctx context.Context, playerId string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.Players[playerId]
+	if !ok {
+		return ErrNotFound
+	}
+	p.Left = true
+	f.Players[playerId] = p
+	return nil
+}
+
+func (f *Fake) PlayersByGame(ctx context.Context, gameId string) ([]game.Player, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var players []game.Player
+	for _, p := range f.Players {
+		if p.GameId == gameId && !p.Left {
+			players = append(players, p)
+		}
+	}
+	return players, nil
+}
+
+func (f *Fake) SetPlayerAlive(ctx context.Context, gameId string, playerId string, alive bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.Players[playerId]
+	if !ok || p.GameId != gameId {
+		return ErrConflict
+	}
+	p.Alive = alive
+	f.Players[playerId] = p
+	return nil
+}
+
+func (f *Fake) SetItPlayer(ctx context.Context, gameId string, players []game.Player, newItPlayerId string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, p := range players {
+		current, ok := f.Players[p.PlayerId]
+		if !ok {
+			return ErrConflict
+		}
+		current.IsIt = current.PlayerId == newItPlayerId
+		f.Players[p.PlayerId] = current
+	}
+	return nil
+}
+
+func (f *Fake) RecordTag(ctx context.Context, tagger game.Player, target game.Player, event game.TagEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	currentTagger, ok := f.Players[tagger.PlayerId]
+	if !ok || !currentTagger.IsIt {
+		return ErrConflict
+	}
+	currentTarget, ok := f.Players[target.PlayerId]
+	if !ok || !currentTarget.Alive {
+		return ErrConflict
+	}
+
+	currentTagger.IsIt = false
+	currentTagger.TagsMade++
+	f.Players[tagger.PlayerId] = currentTagger
+
+	currentTarget.IsIt = true
+	currentTarget.TimesTagged++
+	currentTarget.LastTaggedAt = event.CreatedAt
+	f.Players[target.PlayerId] = currentTarget
+
+	f.TagLog = append(f.TagLog, event)
+	return nil
+}
+
+func (f *Fake) RecordFreezeTag(ctx context.Context, tagger game.Player, target game.Player, event game.TagEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	currentTagger, ok := f.Players[tagger.PlayerId]
+	if !ok || !currentTagger.IsIt {
+		return ErrConflict
+	}
+	currentTarget, ok := f.Players[target.PlayerId]
+	if !ok || !currentTarget.Alive || currentTarget.Frozen {
+		return ErrConflict
+	}
+
+	currentTagger.TagsMade++
+	f.Players[tagger.PlayerId] = currentTagger
+
+	currentTarget.Frozen = true
+	currentTarget.TimesTagged++
+	currentTarget.LastTaggedAt = event.CreatedAt
+	f.Players[target.PlayerId] = currentTarget
+
+	f.TagLog = append(f.TagLog, event)
+	return nil
+}
+
+func (f *Fake) RecordEliminationTag(ctx context.Context, tagger game.Player, target game.Player, event game.TagEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	currentTagger, ok := f.Players[tagger.PlayerId]
+	if !ok || !currentTagger.IsIt {
+		return ErrConflict
+	}
+	currentTarget, ok := f.Players[target.PlayerId]
+	if !ok || !currentTarget.Alive {
+		return ErrConflict
+	}
+
+	currentTagger.TagsMade++
+	f.Players[tagger.PlayerId] = currentTagger
+
+	currentTarget.Alive = false
+	currentTarget.TimesTagged++
+	currentTarget.LastTaggedAt = event.CreatedAt
+	f.Players[target.PlayerId] = currentTarget
+
+	f.TagLog = append(f.TagLog, event)
+	return nil
+}
+
+func (f *Fake) Unfreeze(ctx context.Context, gameId, playerId string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.Players[playerId]
+	if !ok || p.GameId != gameId || !p.Frozen {
+		return ErrConflict
+	}
+	p.Frozen = false
+	f.Players[playerId] = p
+	return nil
+}
+
+func (f *Fake) PutSession(ctx context.Context, s game.Session) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Sessions[s.TokenHash] = s
+	return nil
+}
+
+func (f *Fake) GetSession(ctx context.Context, tokenHash string) (*game.Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.Sessions[tokenHash]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &s, nil
+}
+
+func (f *Fake) DeleteSession(ctx context.Context, tokenHash string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.Sessions, tokenHash)
+	return nil
+}
+
+func (f *Fake) ArchiveGame(ctx context.Context, archived game.ArchivedGame) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.ArchivedGames[archived.GameId] = archived
+	return nil
+}
+
+func (f *Fake) GetArchivedGame(ctx context.Context, gameId string) (*game.ArchivedGame, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	archived, ok := f.ArchivedGames[gameId]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &archived, nil
+}
+
+func (f *Fake) ListArchivedGamesByOwner(ctx context.Context, ownerId string, limit int, cursor string) ([]game.ArchivedGame, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var games []game.ArchivedGame
+	for _, g := range f.ArchivedGames {
+		if g.OwnerId == ownerId {
+			games = append(games, g)
+		}
+	}
+	sort.Slice(games, func(i, j int) bool { return games[i].EndedAt > games[j].EndedAt })
+	if limit > 0 && len(games) > limit {
+		games = games[:limit]
+	}
+	return games, "", nil
+}
+
+func (f *Fake) IncrementPlayerStats(ctx context.Context, window string, playerId, playerName string, tagsMade int, win bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := playerStatsKey(window, playerId)
+	stats := f.PlayerStats[key]
+	stats.Window = window
+	stats.PlayerId = playerId
+	stats.PlayerName = playerName
+	stats.TotalTags += tagsMade
+	stats.GamesPlayed++
+	if win {
+		stats.Wins++
+	}
+	f.PlayerStats[key] = stats
+	return nil
+}
+
+func (f *Fake) Leaderboard(ctx context.Context, window string, limit int, cursor string) ([]game.PlayerStats, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var entries []game.PlayerStats
+	for _, s := range f.PlayerStats {
+		if s.Window == window {
+			entries = append(entries, s)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].TotalTags > entries[j].TotalTags })
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, "", nil
+}
+
+func (f *Fake) CountPlayerStats(ctx context.Context, window string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for _, s := range f.PlayerStats {
+		if s.Window == window {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *Fake) PutConnection(ctx context.Context, c game.Connection) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Connections[connectionKey(c.GameId, c.ConnectionId)] = c
+	return nil
+}
+
+func (f *Fake) GetConnectionByConnectionId(ctx context.Context, connectionId string) (*game.Connection, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, c := range f.Connections {
+		if c.ConnectionId == connectionId {
+			return &c, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (f *Fake) DeleteConnection(ctx context.Context, gameId, connectionId string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.Connections, connectionKey(gameId, connectionId))
+	return nil
+}
+
+func (f *Fake) ConnectionsByGame(ctx context.Context, gameId string) ([]game.Connection, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var conns []game.Connection
+	for _, c := range f.Connections {
+		if c.GameId == gameId {
+			conns = append(conns, c)
+		}
+	}
+	return conns, nil
+}
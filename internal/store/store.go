@@ -0,0 +1,627 @@
+// Package store provides DynamoDB-backed persistence for games, players,
+// sessions, and tag events behind a Store interface, so callers can swap in
+// a fake for unit tests instead of hitting AWS.
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+
+	"github.com/ArchieAdams/tag-game/internal/game"
+)
+
+// ErrNotFound is returned when a lookup finds no matching item.
+var ErrNotFound = errors.New("not found")
+
+// ErrConflict is returned when a conditional write loses a race, e.g. two
+// concurrent tags, or a duplicate game/player/session being created.
+var ErrConflict = errors.New("conflict")
+
+// Tables names the DynamoDB tables the store reads and writes.
+type Tables struct {
+	Games         string
+	Players       string
+	TagEvents     string
+	Sessions      string
+	ArchivedGames string
+	PlayerStats   string
+	Connections   string
+}
+
+// Store is the persistence boundary for the game API. DynamoStore backs it
+// in production; tests can supply a fake implementation instead.
+type Store interface {
+	CreateGameAndPlayer(ctx context.Context, g game.Game, p game.Player) error
+	GetGame(ctx context.Context, gameId string) (*game.Game, error)
+	DeleteGame(ctx context.Context, gameId string) error
+	StartGameState(ctx context.Context, gameId string, startedAt, endsAt int64) error
+	SetGameState(ctx context.Context, gameId string, started bool) error
+
+	CreatePlayer(ctx context.Context, p game.Player) error
+	GetPlayer(ctx context.Context, playerId string) (*game.Player, error)
+	DeletePlayer(ctx context.Context, playerId string) error
+	// MarkPlayerLeft leaves playerId's row in place but flags it Left, so a
+	// game with Settings.AllowRejoin false can still reject a later rejoin
+	// attempt with the same ID. PlayersByGame excludes left players from its
+	// results.
+	MarkPlayerLeft(ctx context.Context, playerId string) error
+	PlayersByGame(ctx context.Context, gameId string) ([]game.Player, error)
+	SetPlayerAlive(ctx context.Context, gameId string, playerId string, alive bool) error
+	SetItPlayer(ctx context.Context, gameId string, players []game.Player, newItPlayerId string) error
+	RecordTag(ctx context.Context, tagger game.Player, target game.Player, event game.TagEvent) error
+	RecordFreezeTag(ctx context.Context, tagger game.Player, target game.Player, event game.TagEvent) error
+	RecordEliminationTag(ctx context.Context, tagger game.Player, target game.Player, event game.TagEvent) error
+	Unfreeze(ctx context.Context, gameId, playerId string) error
+
+	PutSession(ctx context.Context, s game.Session) error
+	GetSession(ctx context.Context, tokenHash string) (*game.Session, error)
+	DeleteSession(ctx context.Context, tokenHash string) error
+
+	ArchiveGame(ctx context.Context, archived game.ArchivedGame) error
+	GetArchivedGame(ctx context.Context, gameId string) (*game.ArchivedGame, error)
+	ListArchivedGamesByOwner(ctx context.Context, ownerId string, limit int, cursor string) (games []game.ArchivedGame, nextCursor string, err error)
+
+	IncrementPlayerStats(ctx context.Context, window string, playerId, playerName string, tagsMade int, win bool) error
+	Leaderboard(ctx context.Context, window string, limit int, cursor string) (entries []game.PlayerStats, nextCursor string, err error)
+	CountPlayerStats(ctx context.Context, window string) (int, error)
+
+	PutConnection(ctx context.Context, c game.Connection) error
+	GetConnectionByConnectionId(ctx context.Context, connectionId string) (*game.Connection, error)
+	DeleteConnection(ctx context.Context, gameId, connectionId string) error
+	ConnectionsByGame(ctx context.Context, gameId string) ([]game.Connection, error)
+}
+
+// DynamoStore implements Store against real DynamoDB tables.
+type DynamoStore struct {
+	svc    *dynamodb.DynamoDB
+	tables Tables
+}
+
+// New returns a DynamoStore that reads and writes the given tables through svc.
+func New(svc *dynamodb.DynamoDB, tables Tables) *DynamoStore {
+	return &DynamoStore{svc: svc, tables: tables}
+}
+
+func (d *DynamoStore) CreateGameAndPlayer(ctx context.Context, g game.Game, p game.Player) error {
+	gameAV, err := dynamodbattribute.MarshalMap(g)
+	if err != nil {
+		return fmt.Errorf("failed to marshal game item: %w", err)
+	}
+	playerAV, err := dynamodbattribute.MarshalMap(p)
+	if err != nil {
+		return fmt.Errorf("failed to marshal player item: %w", err)
+	}
+
+	_, err = d.svc.TransactWriteItemsWithContext(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				Put: &dynamodb.Put{
+					TableName:           aws.String(d.tables.Games),
+					Item:                gameAV,
+					ConditionExpression: aws.String("attribute_not_exists(gameId)"),
+				},
+			},
+			{
+				Put: &dynamodb.Put{
+					TableName:           aws.String(d.tables.Players),
+					Item:                playerAV,
+					ConditionExpression: aws.String("attribute_not_exists(playerId)"),
+				},
+			},
+		},
+	})
+	if err != nil {
+		var canceled *dynamodb.TransactionCanceledException
+		if errors.As(err, &canceled) {
+			return ErrConflict
+		}
+		return fmt.Errorf("transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+func (d *DynamoStore) GetGame(ctx context.Context, gameId string) (*game.Game, error) {
+	result, err := d.svc.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.tables.Games),
+		Key: map[string]*dynamodb.AttributeValue{
+			"gameId": {S: aws.String(gameId)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	if result.Item == nil {
+		return nil, ErrNotFound
+	}
+
+	var g game.Game
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal game: %w", err)
+	}
+	return &g, nil
+}
+
+func (d *DynamoStore) DeleteGame(ctx context.Context, gameId string) error {
+	_, err := d.svc.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(d.tables.Games),
+		Key: map[string]*dynamodb.AttributeValue{
+			"gameId": {S: aws.String(gameId)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete game: %w", err)
+	}
+
+	players, err := d.PlayersByGame(ctx, gameId)
+	if err != nil {
+		return fmt.Errorf("failed to query players by gameId: %w", err)
+	}
+	for _, p := range players {
+		if err := d.DeletePlayer(ctx, p.PlayerId); err != nil {
+			return fmt.Errorf("failed to delete player %s: %w", p.PlayerId, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DynamoStore) StartGameState(ctx context.Context, gameId string, startedAt, endsAt int64) error {
+	_, err := d.svc.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(d.tables.Games),
+		Key: map[string]*dynamodb.AttributeValue{
+			"gameId": {S: aws.String(gameId)},
+		},
+		UpdateExpression: aws.String("SET hasGameStarted = :state, startedAt = :startedAt, endsAt = :endsAt"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":state":     {BOOL: aws.Bool(true)},
+			":startedAt": {N: aws.String(fmt.Sprintf("%d", startedAt))},
+			":endsAt":    {N: aws.String(fmt.Sprintf("%d", endsAt))},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update game state: %w", err)
+	}
+	return nil
+}
+
+func (d *DynamoStore) SetGameState(ctx context.Context, gameId string, started bool) error {
+	_, err := d.svc.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(d.tables.Games),
+		Key: map[string]*dynamodb.AttributeValue{
+			"gameId": {S: aws.String(gameId)},
+		},
+		UpdateExpression: aws.String("SET hasGameStarted = :state"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":state": {BOOL: aws.Bool(started)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update game state: %w", err)
+	}
+	return nil
+}
+
+func (d *DynamoStore) CreatePlayer(ctx context.Context, p game.Player) error {
+	av, err := dynamodbattribute.MarshalMap(p)
+	if err != nil {
+		return fmt.Errorf("failed to marshal player: %w", err)
+	}
+
+	_, err = d.svc.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(d.tables.Players),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(playerId)"),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return ErrConflict
+		}
+		return fmt.Errorf("failed to put player: %w", err)
+	}
+	return nil
+}
+
+func (d *DynamoStore) GetPlayer(ctx context.Context, playerId string) (*game.Player, error) {
+	result, err := d.svc.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.tables.Players),
+		Key: map[string]*dynamodb.AttributeValue{
+			"playerId": {S: aws.String(playerId)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+	if result.Item == nil {
+		return nil, ErrNotFound
+	}
+
+	var p game.Player
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal player: %w", err)
+	}
+	return &p, nil
+}
+
+func (d *DynamoStore) DeletePlayer(ctx context.Context, playerId string) error {
+	_, err := d.svc.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(d.tables.Players),
+		Key: map[string]*dynamodb.AttributeValue{
+			"playerId": {S: aws.String(playerId)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete player: %w", err)
+	}
+	return nil
+}
+
+// MarkPlayerLeft sets playerId's Left flag instead of deleting their row, so
+// attribute_not_exists(playerId) in CreatePlayer keeps rejecting a rejoin
+// under the same ID for games that disallow it.
+func (d *DynamoStore) MarkPlayerLeft(ctx context.Context, playerId string) error {
+	_, err := d.svc.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(d.tables.Players),
+		Key: map[string]*dynamodb.AttributeValue{
+			"playerId": {S: aws.String(playerId)},
+		},
+		UpdateExpression: aws.String("SET #left = :true"),
+		ExpressionAttributeNames: map[string]*string{
+			"#left": aws.String("left"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":true": {BOOL: aws.Bool(true)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark player left: %w", err)
+	}
+	return nil
+}
+
+func (d *DynamoStore) PlayersByGame(ctx context.Context, gameId string) ([]game.Player, error) {
+	queryResult, err := d.svc.QueryWithContext(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(d.tables.Players),
+		IndexName:              aws.String("gameIdIndex"),
+		KeyConditionExpression: aws.String("gameId = :gid"),
+		FilterExpression:       aws.String("attribute_not_exists(#left) OR #left = :false"),
+		ExpressionAttributeNames: map[string]*string{
+			"#left": aws.String("left"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":gid":   {S: aws.String(gameId)},
+			":false": {BOOL: aws.Bool(false)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query players by gameId: %w", err)
+	}
+
+	var players []game.Player
+	for _, item := range queryResult.Items {
+		var p game.Player
+		if err := dynamodbattribute.UnmarshalMap(item, &p); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal player: %w", err)
+		}
+		players = append(players, p)
+	}
+	return players, nil
+}
+
+func (d *DynamoStore) SetPlayerAlive(ctx context.Context, gameId string, playerId string, alive bool) error {
+	_, err := d.svc.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(d.tables.Players),
+		Key: map[string]*dynamodb.AttributeValue{
+			"playerId": {S: aws.String(playerId)},
+		},
+		UpdateExpression:    aws.String("SET alive = :alive"),
+		ConditionExpression: aws.String("gameId = :gid"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":alive": {BOOL: aws.Bool(alive)},
+			":gid":   {S: aws.String(gameId)},
+		},
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return ErrConflict
+		}
+		return fmt.Errorf("failed to set player alive state: %w", err)
+	}
+	return nil
+}
+
+// SetItPlayer atomically flips IsIt off for every player in players and on
+// for newItPlayerId, so a game never ends up with zero or more than one "it".
+func (d *DynamoStore) SetItPlayer(ctx context.Context, gameId string, players []game.Player, newItPlayerId string) error {
+	transactItems := make([]*dynamodb.TransactWriteItem, 0, len(players))
+	for _, p := range players {
+		transactItems = append(transactItems, &dynamodb.TransactWriteItem{
+			Update: &dynamodb.Update{
+				TableName: aws.String(d.tables.Players),
+				Key: map[string]*dynamodb.AttributeValue{
+					"playerId": {S: aws.String(p.PlayerId)},
+				},
+				UpdateExpression:    aws.String("SET isIt = :isIt"),
+				ConditionExpression: aws.String("attribute_exists(playerId)"),
+				ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+					":isIt": {BOOL: aws.Bool(p.PlayerId == newItPlayerId)},
+				},
+			},
+		})
+	}
+
+	_, err := d.svc.TransactWriteItemsWithContext(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: transactItems})
+	if err != nil {
+		var canceled *dynamodb.TransactionCanceledException
+		if errors.As(err, &canceled) {
+			return ErrConflict
+		}
+		return fmt.Errorf("failed to set it player: %w", err)
+	}
+	return nil
+}
+
+// RecordTag flips "it" from tagger to target and appends a TagEvent in a
+// single transaction. The condition on the tagger's isIt flag is what makes
+// this safe under concurrency: once one tag succeeds the tagger's isIt flips
+// to false, so a racing tag attempt fails its conditional update instead of
+// also succeeding.
+func (d *DynamoStore) RecordTag(ctx context.Context, tagger game.Player, target game.Player, event game.TagEvent) error {
+	eventAV, err := dynamodbattribute.MarshalMap(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tag event: %w", err)
+	}
+
+	_, err = d.svc.TransactWriteItemsWithContext(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				Update: &dynamodb.Update{
+					TableName: aws.String(d.tables.Players),
+					Key: map[string]*dynamodb.AttributeValue{
+						"playerId": {S: aws.String(tagger.PlayerId)},
+					},
+					UpdateExpression:    aws.String("SET isIt = :false, tagsMade = tagsMade + :one"),
+					ConditionExpression: aws.String("isIt = :true"),
+					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+						":false": {BOOL: aws.Bool(false)},
+						":true":  {BOOL: aws.Bool(true)},
+						":one":   {N: aws.String("1")},
+					},
+				},
+			},
+			{
+				Update: &dynamodb.Update{
+					TableName: aws.String(d.tables.Players),
+					Key: map[string]*dynamodb.AttributeValue{
+						"playerId": {S: aws.String(target.PlayerId)},
+					},
+					UpdateExpression:    aws.String("SET isIt = :true, timesTagged = timesTagged + :one, lastTaggedAt = :now"),
+					ConditionExpression: aws.String("alive = :true"),
+					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+						":true": {BOOL: aws.Bool(true)},
+						":one":  {N: aws.String("1")},
+						":now":  {N: aws.String(fmt.Sprintf("%d", event.CreatedAt))},
+					},
+				},
+			},
+			{
+				Put: &dynamodb.Put{
+					TableName: aws.String(d.tables.TagEvents),
+					Item:      eventAV,
+				},
+			},
+		},
+	})
+	if err != nil {
+		var canceled *dynamodb.TransactionCanceledException
+		if errors.As(err, &canceled) {
+			return ErrConflict
+		}
+		return fmt.Errorf("failed to record tag: %w", err)
+	}
+
+	return nil
+}
+
+// RecordFreezeTag freezes target in place and appends a TagEvent in a single
+// transaction. Unlike RecordTag, the tagger's isIt flag never changes:
+// freeze mode has one or more taggers hunting the whole round.
+func (d *DynamoStore) RecordFreezeTag(ctx context.Context, tagger game.Player, target game.Player, event game.TagEvent) error {
+	eventAV, err := dynamodbattribute.MarshalMap(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tag event: %w", err)
+	}
+
+	_, err = d.svc.TransactWriteItemsWithContext(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				Update: &dynamodb.Update{
+					TableName: aws.String(d.tables.Players),
+					Key: map[string]*dynamodb.AttributeValue{
+						"playerId": {S: aws.String(tagger.PlayerId)},
+					},
+					UpdateExpression:    aws.String("SET tagsMade = tagsMade + :one"),
+					ConditionExpression: aws.String("isIt = :true"),
+					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+						":true": {BOOL: aws.Bool(true)},
+						":one":  {N: aws.String("1")},
+					},
+				},
+			},
+			{
+				Update: &dynamodb.Update{
+					TableName: aws.String(d.tables.Players),
+					Key: map[string]*dynamodb.AttributeValue{
+						"playerId": {S: aws.String(target.PlayerId)},
+					},
+					UpdateExpression:    aws.String("SET frozen = :true, timesTagged = timesTagged + :one, lastTaggedAt = :now"),
+					ConditionExpression: aws.String("alive = :true AND frozen = :false"),
+					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+						":true":  {BOOL: aws.Bool(true)},
+						":false": {BOOL: aws.Bool(false)},
+						":one":   {N: aws.String("1")},
+						":now":   {N: aws.String(fmt.Sprintf("%d", event.CreatedAt))},
+					},
+				},
+			},
+			{
+				Put: &dynamodb.Put{
+					TableName: aws.String(d.tables.TagEvents),
+					Item:      eventAV,
+				},
+			},
+		},
+	})
+	if err != nil {
+		var canceled *dynamodb.TransactionCanceledException
+		if errors.As(err, &canceled) {
+			return ErrConflict
+		}
+		return fmt.Errorf("failed to record freeze tag: %w", err)
+	}
+
+	return nil
+}
+
+// RecordEliminationTag eliminates target and appends a TagEvent in a single
+// transaction. Like RecordFreezeTag, the tagger's isIt flag never changes.
+func (d *DynamoStore) RecordEliminationTag(ctx context.Context, tagger game.Player, target game.Player, event game.TagEvent) error {
+	eventAV, err := dynamodbattribute.MarshalMap(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tag event: %w", err)
+	}
+
+	_, err = d.svc.TransactWriteItemsWithContext(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				Update: &dynamodb.Update{
+					TableName: aws.String(d.tables.Players),
+					Key: map[string]*dynamodb.AttributeValue{
+						"playerId": {S: aws.String(tagger.PlayerId)},
+					},
+					UpdateExpression:    aws.String("SET tagsMade = tagsMade + :one"),
+					ConditionExpression: aws.String("isIt = :true"),
+					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+						":true": {BOOL: aws.Bool(true)},
+						":one":  {N: aws.String("1")},
+					},
+				},
+			},
+			{
+				Update: &dynamodb.Update{
+					TableName: aws.String(d.tables.Players),
+					Key: map[string]*dynamodb.AttributeValue{
+						"playerId": {S: aws.String(target.PlayerId)},
+					},
+					UpdateExpression:    aws.String("SET alive = :false, timesTagged = timesTagged + :one, lastTaggedAt = :now"),
+					ConditionExpression: aws.String("alive = :true"),
+					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+						":false": {BOOL: aws.Bool(false)},
+						":true":  {BOOL: aws.Bool(true)},
+						":one":   {N: aws.String("1")},
+						":now":   {N: aws.String(fmt.Sprintf("%d", event.CreatedAt))},
+					},
+				},
+			},
+			{
+				Put: &dynamodb.Put{
+					TableName: aws.String(d.tables.TagEvents),
+					Item:      eventAV,
+				},
+			},
+		},
+	})
+	if err != nil {
+		var canceled *dynamodb.TransactionCanceledException
+		if errors.As(err, &canceled) {
+			return ErrConflict
+		}
+		return fmt.Errorf("failed to record elimination tag: %w", err)
+	}
+
+	return nil
+}
+
+// Unfreeze clears a frozen player's Frozen flag, provided they're currently
+// frozen in gameId.
+func (d *DynamoStore) Unfreeze(ctx context.Context, gameId, playerId string) error {
+	_, err := d.svc.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(d.tables.Players),
+		Key: map[string]*dynamodb.AttributeValue{
+			"playerId": {S: aws.String(playerId)},
+		},
+		UpdateExpression:    aws.String("SET frozen = :false"),
+		ConditionExpression: aws.String("gameId = :gid AND frozen = :true"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":false": {BOOL: aws.Bool(false)},
+			":true":  {BOOL: aws.Bool(true)},
+			":gid":   {S: aws.String(gameId)},
+		},
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return ErrConflict
+		}
+		return fmt.Errorf("failed to unfreeze player: %w", err)
+	}
+	return nil
+}
+
+func (d *DynamoStore) PutSession(ctx context.Context, s game.Session) error {
+	av, err := dynamodbattribute.MarshalMap(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	_, err = d.svc.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(d.tables.Sessions),
+		Item:      av,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+func (d *DynamoStore) GetSession(ctx context.Context, tokenHash string) (*game.Session, error) {
+	result, err := d.svc.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.tables.Sessions),
+		Key: map[string]*dynamodb.AttributeValue{
+			"tokenHash": {S: aws.String(tokenHash)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up session: %w", err)
+	}
+	if result.Item == nil {
+		return nil, ErrNotFound
+	}
+
+	var s game.Session
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &s); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+	}
+	return &s, nil
+}
+
+func (d *DynamoStore) DeleteSession(ctx context.Context, tokenHash string) error {
+	_, err := d.svc.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(d.tables.Sessions),
+		Key: map[string]*dynamodb.AttributeValue{
+			"tokenHash": {S: aws.String(tokenHash)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Package ws pushes real-time game events to clients connected over an API
+// Gateway WebSocket API. A broadcast is always best-effort: the HTTP API
+// remains the source of truth for game state, and works the same whether or
+// not any client is listening.
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigatewaymanagementapi"
+
+	"github.com/ArchieAdams/tag-game/internal/game"
+	"github.com/ArchieAdams/tag-game/internal/store"
+)
+
+// Pusher sends a single payload to one connected client.
+type Pusher interface {
+	Post(ctx context.Context, connectionId string, data []byte) error
+}
+
+// APIGatewayPusher posts to connections through API Gateway's WebSocket
+// management API.
+type APIGatewayPusher struct {
+	client *apigatewaymanagementapi.ApiGatewayManagementApi
+}
+
+// NewAPIGatewayPusher returns a Pusher that posts to connections through the
+// management endpoint for a WebSocket API stage, e.g.
+// "https://{api-id}.execute-api.{region}.amazonaws.com/{stage}".
+func NewAPIGatewayPusher(endpoint string) *APIGatewayPusher {
+	sess := session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+	client := apigatewaymanagementapi.New(sess, aws.NewConfig().WithEndpoint(endpoint))
+	return &APIGatewayPusher{client: client}
+}
+
+func (p *APIGatewayPusher) Post(ctx context.Context, connectionId string, data []byte) error {
+	_, err := p.client.PostToConnectionWithContext(ctx, &apigatewaymanagementapi.PostToConnectionInput{
+		ConnectionId: aws.String(connectionId),
+		Data:         data,
+	})
+	return err
+}
+
+// IsGone reports whether err is the GoneException API Gateway returns for a
+// connection that's no longer open.
+func IsGone(err error) bool {
+	var gone *apigatewaymanagementapi.GoneException
+	return errors.As(err, &gone)
+}
+
+// Broadcaster fans an event out to every connection subscribed to a game,
+// pruning any connection that's gone stale along the way.
+type Broadcaster struct {
+	store  store.Store
+	pusher Pusher
+}
+
+// NewBroadcaster returns a Broadcaster that looks up connections in st and
+// delivers events through pusher.
+func NewBroadcaster(st store.Store, pusher Pusher) *Broadcaster {
+	return &Broadcaster{store: st, pusher: pusher}
+}
+
+// Broadcast JSON-encodes event and delivers it to every connection currently
+// subscribed to gameId, in parallel. It logs and continues on a
+// per-connection failure rather than returning an error, since a broadcast
+// is a best-effort notification, never the source of truth for game state.
+func (b *Broadcaster) Broadcast(ctx context.Context, gameId string, event any) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("failed to marshal broadcast event for game %s: %v", gameId, err)
+		return
+	}
+
+	conns, err := b.store.ConnectionsByGame(ctx, gameId)
+	if err != nil {
+		log.Printf("failed to list connections for game %s: %v", gameId, err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, conn := range conns {
+		wg.Add(1)
+		go func(conn game.Connection) {
+			defer wg.Done()
+			if err := b.pusher.Post(ctx, conn.ConnectionId, data); err != nil {
+				if IsGone(err) {
+					if delErr := b.store.DeleteConnection(ctx, conn.GameId, conn.ConnectionId); delErr != nil {
+						log.Printf("failed to clean up gone connection %s: %v", conn.ConnectionId, delErr)
+					}
+					return
+				}
+				log.Printf("failed to push to connection %s: %v", conn.ConnectionId, err)
+			}
+		}(conn)
+	}
+	wg.Wait()
+}